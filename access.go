@@ -0,0 +1,54 @@
+package csvstruct
+
+import "errors"
+
+// ErrForbiddenColumn is the problem of a CSV header column that is not allowed
+// by the policy given with WithColumnAccess.
+var ErrForbiddenColumn = errors.New("column is not allowed")
+
+// AccessMode is the handling of the CSV header columns that are not allowed by
+// the policy given with WithColumnAccess.
+type AccessMode int
+
+const (
+	// AccessReject makes Read return a HeaderError whose problems of the
+	// forbidden columns wrap ErrForbiddenColumn.
+	AccessReject AccessMode = iota
+	// AccessStrip ignores the forbidden columns, and notifies observers with
+	// an EventWarning per column, whose error is a *ColumnError that wraps
+	// ErrForbiddenColumn.
+	AccessStrip
+)
+
+// checkAccess checks whether the column `column`, whose descriptor is
+// `descriptor`, is allowed by the policy given with WithColumnAccess, and
+// returns the descriptor to use, which ignores the column if it's stripped.
+func (r *Reader[T]) checkAccess(columnNum int, column string, descriptor colDescriptor) (colDescriptor, error) {
+	if r.options.allowColumn == nil || descriptor.ignored || r.options.allowColumn(descriptor.qualName()) {
+		return descriptor, nil
+	}
+
+	if r.options.accessMode == AccessReject {
+		return descriptor, ErrForbiddenColumn
+	}
+
+	r.emit(Event{Kind: EventWarning, Line: r.fieldLine(0), Err: &ColumnError{Column: columnNum + 1, Name: column, Err: ErrForbiddenColumn}})
+	descriptor = ignoredDescriptor(descriptor.qualName())
+	descriptor.forbidden = true
+	return descriptor, nil
+}
+
+// WithColumnAccess restricts the columns that can be decoded to the ones whose
+// qualified names, e.g., 'MyComponent.MyField', are allowed by `allow`, e.g.,
+// so that user uploads to multi-tenant import endpoints can't set 'Admin.*'
+// columns. The forbidden columns are handled according to `mode`. See
+// AccessMode.
+//
+// Stripped columns are not captured by Reader.UnknownCells nor by the field
+// tagged with `csv:",unknown"`, so they are never written back.
+func WithColumnAccess(allow func(qualName string) bool, mode AccessMode) Option {
+	return func(o *options) {
+		o.allowColumn = allow
+		o.accessMode = mode
+	}
+}
@@ -0,0 +1,71 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Admin struct {
+	Role string
+}
+
+type Upload struct {
+	Info    *Info
+	Admin   *Admin
+	Unknown map[string]string `csv:",unknown"`
+}
+
+const uploadData = `Info.Name,Admin.Role
+Alex,owner
+`
+
+func allowUserColumns(qualName string) bool {
+	return !strings.HasPrefix(qualName, "Admin.")
+}
+
+func TestReaderColumnAccess_Reject(t *testing.T) {
+	reader := csvstruct.NewReader[Upload](csv.NewReader(strings.NewReader(uploadData)), csvstruct.WithColumnAccess(allowUserColumns, csvstruct.AccessReject))
+
+	var got Upload
+	err := reader.Read(&got)
+
+	var headerErr *csvstruct.HeaderError
+	if !errors.As(err, &headerErr) {
+		t.Fatalf("Read() err = %v; want %T", err, headerErr)
+	}
+	if !errors.Is(err, csvstruct.ErrForbiddenColumn) {
+		t.Errorf("Read() err = %v; want %v", err, csvstruct.ErrForbiddenColumn)
+	}
+}
+
+func TestReaderColumnAccess_Strip(t *testing.T) {
+	var warnings []string
+	observer := func(event csvstruct.Event) {
+		if event.Kind == csvstruct.EventWarning {
+			warnings = append(warnings, event.Err.Error())
+		}
+	}
+
+	reader := csvstruct.NewReader[Upload](csv.NewReader(strings.NewReader(uploadData)), csvstruct.WithColumnAccess(allowUserColumns, csvstruct.AccessStrip), csvstruct.WithObserver(observer))
+
+	var got Upload
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	// Stripped columns are not captured by the unknown field either.
+	want := Upload{Info: &Info{Name: "Alex"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+
+	wantWarnings := []string{"column 2 (Admin.Role): column is not allowed"}
+	if diff := cmp.Diff(wantWarnings, warnings); diff != "" {
+		t.Errorf("EventWarning diff = %v", diff)
+	}
+}
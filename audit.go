@@ -0,0 +1,132 @@
+package csvstruct
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// ColumnAudit describes the cells of a column of the current table, as
+// observed with the WithAudit option.
+type ColumnAudit struct {
+	// Kind of the field of the column.
+	Kind reflect.Kind
+	// Number of non-empty cells of each inferred type, i.e., 'int', 'float',
+	// 'bool', or 'string'.
+	Types map[string]int
+	// Number of distinct non-empty cells, i.e., the cardinality of the column.
+	Distinct int
+	// Lines of the cells whose inferred type disagrees with Kind, e.g., letters
+	// in a number column, which fail to decode.
+	DriftLines []int
+}
+
+// columnAudit is the ColumnAudit of a column, together with its distinct
+// cells.
+type columnAudit struct {
+	ColumnAudit
+	// Distinct non-empty cells of the column.
+	cells map[string]bool
+}
+
+// inferType returns the type of `cell` as it would be inferred by a person
+// reading it, i.e., 'int', 'float', 'bool', or 'string'.
+func inferType(cell string) string {
+	if _, err := strconv.ParseInt(cell, 10, 64); err == nil {
+		return "int"
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return "float"
+	}
+	if _, err := strconv.ParseBool(cell); err == nil {
+		return "bool"
+	}
+	return "string"
+}
+
+// drifts returns whether the non-empty `cell` can't be parsed as the kind of
+// the column described by `descriptor`, regardless of its range. Only number
+// and bool fields that are decoded without codecs or unions can drift.
+func (r *Reader[T]) drifts(descriptor *colDescriptor, cell string) bool {
+	var err error
+	switch {
+	case descriptor.isNumber():
+		switch descriptor.kind {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			_, err = strconv.ParseInt(cell, 10, 64)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			_, err = strconv.ParseUint(cell, 10, 64)
+		default:
+			_, err = strconv.ParseFloat(cell, 64)
+		}
+	case descriptor.kind == reflect.Bool && descriptor.codec == nil && descriptor.union == nil:
+		_, err = r.options.parseBool(cell)
+	}
+	return err != nil
+}
+
+// auditRow records the non-empty cells of `row` in the audit of the current
+// table, before the row is decoded, so that the audit includes the rows that
+// fail to decode. Only used with the WithAudit option.
+func (r *Reader[T]) auditRow(row []string) {
+	if r.audit == nil {
+		r.audit = map[string]*columnAudit{}
+	}
+
+	for columnNum, cell := range row {
+		descriptor := &r.colDescriptors[columnNum]
+		if descriptor.ignored || len(cell) == 0 {
+			continue
+		}
+
+		qualName := descriptor.qualName()
+		audit, ok := r.audit[qualName]
+		if !ok {
+			audit = &columnAudit{ColumnAudit{Kind: descriptor.kind, Types: map[string]int{}}, map[string]bool{}}
+			r.audit[qualName] = audit
+		}
+
+		audit.Types[inferType(cell)]++
+		if !audit.cells[cell] {
+			audit.cells[cell] = true
+			audit.Distinct++
+		}
+
+		if r.drifts(descriptor, cell) {
+			audit.DriftLines = append(audit.DriftLines, r.fieldLine(columnNum))
+		}
+	}
+}
+
+// Audit returns the audit of the columns of the current table, or of the most
+// recent table after it ends, indexed by qualified name, e.g.,
+// 'MyComponent.MyField'. Only columns with non-empty cells are included. Only
+// used with the WithAudit option.
+func (r *Reader[T]) Audit() map[string]ColumnAudit {
+	audits := make(map[string]ColumnAudit, len(r.audit))
+	for qualName, audit := range r.audit {
+		audits[qualName] = ColumnAudit{
+			Kind:       audit.Kind,
+			Types:      maps.Clone(audit.Types),
+			Distinct:   audit.Distinct,
+			DriftLines: slices.Clone(audit.DriftLines),
+		}
+	}
+	return audits
+}
+
+// WithAudit enables the audit mode, which records the inferred types and the
+// cardinality of the cells of each column, returned by Reader.Audit, e.g., to
+// produce data quality reports for data owners.
+//
+// The audit also reports the lines of the cells of number and bool fields
+// whose inferred type disagrees with the kind of the field, e.g., letters in a
+// number column. Auditing doesn't change decoding, i.e., Read still returns
+// the errors of such cells, so the audit of a whole table with such cells
+// requires the WithSkipInvalidRows option.
+func WithAudit() Option {
+	return func(o *options) {
+		o.audit = true
+	}
+}
@@ -0,0 +1,79 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderAudit(t *testing.T) {
+	const data = `Info.Name,Info.Class,Attributes.HP,Attributes.Damage
+Alex,Fighter,100,10
+Jayden,Fighter,ninety,2.5
+Mary,Queen,,
+`
+	var skipped []error
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithAudit(), csvstruct.WithSkipInvalidRows(func(err error) {
+		skipped = append(skipped, err)
+	}))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Prefab{
+		{Info: &Info{"Alex", "Fighter"}, Attributes: &Attributes{100, 10}},
+		{Info: &Info{"Mary", "Queen"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+	if len(skipped) != 1 {
+		t.Errorf("skipped rows = %v; want 1 row", skipped)
+	}
+
+	wantAudit := map[string]csvstruct.ColumnAudit{
+		"Info.Name":         {Kind: reflect.String, Types: map[string]int{"string": 3}, Distinct: 3},
+		"Info.Class":        {Kind: reflect.String, Types: map[string]int{"string": 3}, Distinct: 2},
+		"Attributes.HP":     {Kind: reflect.Int, Types: map[string]int{"int": 1, "string": 1}, Distinct: 2, DriftLines: []int{3}},
+		"Attributes.Damage": {Kind: reflect.Int, Types: map[string]int{"int": 1, "float": 1}, Distinct: 2, DriftLines: []int{3}},
+	}
+	if diff := cmp.Diff(wantAudit, reader.Audit()); diff != "" {
+		t.Errorf("Audit() diff = %v", diff)
+	}
+}
+
+func TestReaderAudit_ParseError(t *testing.T) {
+	const data = `Info.Name,Attributes.HP
+Jayden,ninety
+`
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithAudit())
+
+	var got Prefab
+	if err := reader.Read(&got); err == nil {
+		t.Fatalf("Read() err = %v; want error", err)
+	}
+	if got := reader.Audit()["Attributes.HP"].DriftLines; !cmp.Equal(got, []int{2}) {
+		t.Errorf("Audit() DriftLines = %v; want %v", got, []int{2})
+	}
+}
+
+func TestReaderAudit_Disabled(t *testing.T) {
+	const data = `Info.Name,Attributes.HP
+Jayden,ninety
+`
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)))
+
+	var got Prefab
+	if err := reader.Read(&got); err == nil {
+		t.Fatalf("Read() err = %v; want error", err)
+	}
+	if audit := reader.Audit(); len(audit) != 0 {
+		t.Errorf("Audit() = %v; want empty", audit)
+	}
+}
@@ -0,0 +1,61 @@
+package csvstruct
+
+// isBlankRow returns whether all the cells of `row` are empty.
+func isBlankRow(row []string) bool {
+	for _, cell := range row {
+		if len(cell) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// nextTable ends the current table at a blank row, which was just read, and
+// reads the first data row of the next table into `t`. The next table starts
+// at the next row that is not blank, which is its CSV header.
+func (r *Reader[T]) nextTable(t *T) error {
+	row, err := r.reader.Read()
+	for err == nil && isBlankRow(row) {
+		row, err = r.reader.Read()
+	}
+	if err != nil {
+		// At the end of the CSV data, Read ends the current table.
+		return err
+	}
+
+	r.emit(Event{Kind: EventTableEnded, Line: r.fieldLine(0)})
+	r.Clear()
+	if err := r.parseHeader(row); err != nil {
+		return err
+	}
+	return r.parseRow(t)
+}
+
+// WithBlankRowSeparators separates tables in the same CSV data with blank
+// rows, i.e., rows whose cells are all empty, e.g., ',,,'. At a blank row, the
+// current table ends and the next row that is not blank is the CSV header of
+// the next table, which Read continues with, i.e., without calling Clear.
+// Observers are notified with EventTableEnded and EventTableStarted.
+//
+// Empty lines are skipped by csv.Reader, so they don't separate tables.
+// Since tables can have different numbers of columns, the FieldsPerRecord
+// field of the underlying CSV reader is set to -1.
+func WithBlankRowSeparators() Option {
+	return func(o *options) {
+		o.blankRowSeparators = true
+	}
+}
+
+// WriteBlankRow ends the current table and writes a blank row, i.e., a row
+// whose cells are all empty, so that the next table can be written to the same
+// CSV data, e.g., by a Writer of a different type sharing the same csv.Writer.
+// Reader recognizes blank rows when it's created with the
+// WithBlankRowSeparators option.
+//
+// The blank row has as many cells as the CSV header, but at least two, since
+// csv.Writer writes a row with a single empty cell as an empty line, which
+// csv.Reader skips. Like NewTable, the next Write writes the CSV header again.
+func (w *Writer[T]) WriteBlankRow() error {
+	w.hasHeader = false
+	return w.writer.Write(make([]string, max(len(w.columns), 2)))
+}
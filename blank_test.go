@@ -0,0 +1,99 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderBlankRowSeparators(t *testing.T) {
+	const data = `Info.Name,Info.Class
+Alex,Fighter
+,
+,
+Attributes.HP,Info.Name,Player
+90,Jayden,
+,,
+`
+
+	var kinds []csvstruct.EventKind
+	observer := func(event csvstruct.Event) {
+		if event.Kind == csvstruct.EventTableStarted || event.Kind == csvstruct.EventTableEnded {
+			kinds = append(kinds, event.Kind)
+		}
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithBlankRowSeparators(), csvstruct.WithObserver(observer))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Prefab{
+		{Info: &Info{"Alex", "Fighter"}},
+		{Info: &Info{Name: "Jayden"}, Attributes: &Attributes{HP: 90}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+
+	wantKinds := []csvstruct.EventKind{csvstruct.EventTableStarted, csvstruct.EventTableEnded, csvstruct.EventTableStarted, csvstruct.EventTableEnded}
+	if diff := cmp.Diff(wantKinds, kinds); diff != "" {
+		t.Errorf("events diff = %v", diff)
+	}
+}
+
+func TestWriter_WriteBlankRow(t *testing.T) {
+	var buf strings.Builder
+	csvWriter := csv.NewWriter(&buf)
+
+	writer := csvstruct.NewWriter[Prefab](csvWriter)
+	if err := writer.Write(testPrefabs[0]); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.WriteBlankRow(); err != nil {
+		t.Fatalf("WriteBlankRow() err = %v; want %v", err, nil)
+	}
+
+	infoWriter := csvstruct.NewWriter[Prefab](csvWriter, csvstruct.WithWriteComponents("Info"))
+	if err := infoWriter.Write(testPrefabs[2]); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := infoWriter.WriteBlankRow(); err != nil {
+		t.Fatalf("WriteBlankRow() err = %v; want %v", err, nil)
+	}
+	if err := infoWriter.WriteHeader(); err != nil {
+		t.Fatalf("WriteHeader() err = %v; want %v", err, nil)
+	}
+	if err := infoWriter.WriteHeader(); err != nil {
+		t.Fatalf("WriteHeader() err = %v; want %v", err, nil)
+	}
+	if err := infoWriter.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want := `Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player
+Alex,Fighter,100,10,
+,,,,
+Info.Name,Info.Class
+Mary,Queen
+,
+Info.Name,Info.Class
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatalf("Write() diff = %v", diff)
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(buf.String())), csvstruct.WithBlankRowSeparators())
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff([]Prefab{testPrefabs[0], testPrefabs[2]}, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
@@ -0,0 +1,45 @@
+package csvstruct
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	// Cells that are parsed as true by default.
+	defaultTrueValues = []string{"true", "1", "yes"}
+	// Cells that are parsed as false by default.
+	defaultFalseValues = []string{"false", "0", "no"}
+)
+
+// parseBool parses a cell of a bool field, ignoring case.
+func (o *options) parseBool(cell string) (bool, error) {
+	trueValues, falseValues := o.trueValues, o.falseValues
+	if trueValues == nil && falseValues == nil {
+		trueValues, falseValues = defaultTrueValues, defaultFalseValues
+	}
+
+	for _, value := range trueValues {
+		if strings.EqualFold(cell, value) {
+			return true, nil
+		}
+	}
+	for _, value := range falseValues {
+		if strings.EqualFold(cell, value) {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("invalid bool %q; want one of %q or %q", cell, trueValues, falseValues)
+}
+
+// WithBoolValues sets the cells that are parsed as true and false in bool
+// fields, e.g., 'Y' and 'N'. Case is ignored.
+//
+// By default, bool fields accept 'true', '1', and 'yes' as true, and 'false',
+// '0', and 'no' as false. Empty cells are false, like any other empty cell.
+func WithBoolValues(trueValues, falseValues []string) Option {
+	return func(o *options) {
+		o.trueValues = trueValues
+		o.falseValues = falseValues
+	}
+}
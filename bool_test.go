@@ -0,0 +1,103 @@
+package csvstruct_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Flags struct {
+	Hostile  bool
+	Flying   bool
+	Boss     bool
+	Wanders  bool
+	Priority int
+}
+
+type Npc struct {
+	Flags *Flags
+}
+
+func TestReaderBool(t *testing.T) {
+	const data = `Flags.Hostile,Flags.Flying,Flags.Boss,Flags.Wanders,Flags.Priority
+true,1,YES,,1
+False,0,no,,2
+`
+
+	want := []Npc{
+		{&Flags{true, true, true, false, 1}},
+		{&Flags{false, false, false, false, 2}},
+	}
+
+	reader := csvstruct.NewReader[Npc](csv.NewReader(strings.NewReader(data)))
+
+	for _, want := range want {
+		var got Npc
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Read() diff = %v", diff)
+		}
+	}
+}
+
+func TestReaderBool_Invalid(t *testing.T) {
+	const data = `Flags.Hostile,Flags.Flying
+true,maybe
+`
+
+	reader := csvstruct.NewReader[Npc](csv.NewReader(strings.NewReader(data)))
+
+	var got Npc
+	err := reader.Read(&got)
+	if err == nil || !strings.HasPrefix(err.Error(), `line 2, column 2 (Flags.Flying): invalid bool "maybe"`) {
+		t.Errorf("Read() err = %v; want invalid bool error", err)
+	}
+}
+
+func TestWithBoolValues(t *testing.T) {
+	const data = `Flags.Hostile,Flags.Flying
+Y,n
+`
+
+	reader := csvstruct.NewReader[Npc](csv.NewReader(strings.NewReader(data)), csvstruct.WithBoolValues([]string{"Y"}, []string{"N"}))
+
+	var got Npc
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Npc{&Flags{Hostile: true}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+
+	// The default values are no longer accepted.
+	reader = csvstruct.NewReader[Npc](csv.NewReader(strings.NewReader("Flags.Hostile\ntrue\n")), csvstruct.WithBoolValues([]string{"Y"}, []string{"N"}))
+	if err := reader.Read(&got); err == nil {
+		t.Errorf("Read() err = %v; want invalid bool error", err)
+	}
+}
+
+func TestWriterBool(t *testing.T) {
+	var buf bytes.Buffer
+	writer := csvstruct.NewWriter[Npc](csv.NewWriter(&buf))
+
+	if err := writer.Write(Npc{&Flags{Hostile: true, Priority: 3}}); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want := "Flags.Hostile,Flags.Flying,Flags.Boss,Flags.Wanders,Flags.Priority\ntrue,false,false,false,3\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+}
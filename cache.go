@@ -0,0 +1,247 @@
+package csvstruct
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+)
+
+// cacheVersion is the version of the cache format. It's part of the cache key,
+// so incrementing it invalidates all cached tables.
+const cacheVersion = 1
+
+// Cache stores decoded tables on disk, keyed by a hash of the CSV data and the
+// schema, so that unchanged CSV files don't need to be parsed again.
+//
+// Cached tables are encoded with encoding/gob.
+type Cache struct {
+	// Directory where cached tables are stored. It's created if it doesn't
+	// exist.
+	Dir string
+}
+
+// writeTypeFingerprint writes a description of the type `typ` to `hash`,
+// including the names and types of all struct fields, recursively.
+func writeTypeFingerprint(hash hash.Hash, typ reflect.Type, visited map[reflect.Type]bool) {
+	fmt.Fprintf(hash, "%s:%s", typ.String(), typ.Kind())
+
+	if visited[typ] {
+		return
+	}
+	visited[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		fmt.Fprint(hash, "[")
+		writeTypeFingerprint(hash, typ.Elem(), visited)
+		fmt.Fprint(hash, "]")
+	case reflect.Map:
+		fmt.Fprint(hash, "[")
+		writeTypeFingerprint(hash, typ.Key(), visited)
+		writeTypeFingerprint(hash, typ.Elem(), visited)
+		fmt.Fprint(hash, "]")
+	case reflect.Struct:
+		fmt.Fprint(hash, "{")
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			fmt.Fprintf(hash, "%s %q ", field.Name, field.Tag)
+			writeTypeFingerprint(hash, field.Type, visited)
+			fmt.Fprint(hash, ";")
+		}
+		fmt.Fprint(hash, "}")
+	}
+}
+
+// checkCacheable returns an error if the options can't be part of a cache key,
+// i.e., they affect decoding through functions or external data, e.g.,
+// middleware, which can't be fingerprinted.
+func (o *options) checkCacheable() error {
+	var names []string
+	if o.assetFS != nil {
+		names = append(names, "WithAssetFS")
+	}
+	if o.stringTable != nil {
+		names = append(names, "WithStringTable")
+	}
+	if o.allowColumn != nil {
+		names = append(names, "WithColumnAccess")
+	}
+	if o.headerNormalizer != nil {
+		names = append(names, "WithHeaderNormalizer")
+	}
+	if o.middleware != nil {
+		names = append(names, "WithMiddleware")
+	}
+	if o.invalidRowHandler != nil {
+		names = append(names, "WithSkipInvalidRows")
+	}
+	if o.recoverySource != nil {
+		names = append(names, "WithQuoteRecovery")
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("options %v affect decoding but can't be part of a cache key; use LoadAll instead", names)
+	}
+	return nil
+}
+
+// writeFingerprint writes a description of the options that affect how rows
+// are decoded to `hash`, including the codecs registered with RegisterCodec
+// and RegisterNamedCodec. Options that only report on decoding, e.g.,
+// WithObserver, are not included.
+//
+// Codecs are described by their types and values, so changes to the code of
+// a codec are not detected.
+func (o *options) writeFingerprint(hash hash.Hash) {
+	fmt.Fprintf(hash, "sections=%v metadata=%v units=%v trailing=%v blank=%v\n", o.sections, o.metadata, o.units, o.trailingEmptyColumns, o.blankRowSeparators)
+	fmt.Fprintf(hash, "ignoreUnknown=%v schema=%q defaults=%q\n", o.ignoreUnknownColumns, o.schema, o.defaultComponents)
+	fmt.Fprintf(hash, "timeLayout=%q true=%q false=%q utf8=%v\n", o.timeLayout, o.trueValues, o.falseValues, o.utf8Policy)
+	fmt.Fprintf(hash, "emptyNumbers=%v complete=%v caseInsensitive=%v audit=%v\n", o.emptyNumbersAsZero, o.completeComponents, o.caseInsensitiveHeaders, o.audit)
+	fmt.Fprintf(hash, "separators=%q %q %q\n", o.listSeparator, o.pairSeparator, o.keySeparator)
+	fmt.Fprintf(hash, "mapping=%q %q\n", o.mapping.Columns, o.mapping.Converters)
+
+	var lines []string
+	for typ, codec := range o.codecs {
+		lines = append(lines, fmt.Sprintf("codec %s=%T %+v", typ, codec, codec))
+	}
+	globalCodecsMu.RLock()
+	for typ, codec := range globalCodecs {
+		lines = append(lines, fmt.Sprintf("global codec %s=%T %+v", typ, codec, codec))
+	}
+	globalCodecsMu.RUnlock()
+	namedCodecsMu.RLock()
+	for name, codec := range namedCodecs {
+		lines = append(lines, fmt.Sprintf("named codec %s=%T %+v", name, codec, codec))
+	}
+	namedCodecsMu.RUnlock()
+	for qualName, types := range o.unions {
+		for typeName, typ := range types {
+			lines = append(lines, fmt.Sprintf("union %s %s=%s", qualName, typeName, typ))
+		}
+	}
+	slices.Sort(lines)
+	fmt.Fprintf(hash, "%q\n", lines)
+}
+
+// cacheKey returns the key of the rows of type `T` decoded from `data` with
+// the options `opts`, i.e., a hash of the cache version, the type `T`, the
+// options that affect decoding, the schema fingerprint of the first table,
+// and the CSV data.
+func cacheKey[T any](data []byte, opts []Option) (string, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := o.checkCacheable(); err != nil {
+		return "", err
+	}
+
+	// Only the CSV header is read, so observers must not see it.
+	quiet := func(o *options) {
+		o.observers = nil
+		o.deadlineSource = nil
+	}
+	reader := NewReader[T](csv.NewReader(bytes.NewReader(data)), append(slices.Clone(opts), quiet)...)
+	if err := reader.readHeader(); err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "csvstruct cache v%d\n", cacheVersion)
+	writeTypeFingerprint(hash, reflect.TypeFor[T](), map[reflect.Type]bool{})
+	fmt.Fprintln(hash)
+	o.writeFingerprint(hash)
+	fmt.Fprintln(hash, reader.SchemaFingerprint())
+	hash.Write(data)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// LoadCached reads all the rows of the first table of the CSV file `name` in
+// `fsys`.
+//
+// If the same CSV data was previously loaded into the same type `T` with the
+// same options, the rows are decoded from the cache instead of being parsed.
+// Otherwise, the CSV data is parsed and validated like in LoadAll, and the rows
+// are stored in the cache.
+//
+// The options that affect decoding are part of the cache key, and so are the
+// codecs registered with RegisterCodec and RegisterNamedCodec. Options that
+// affect decoding through functions or external data can't be part of it,
+// i.e., WithAssetFS, WithStringTable, WithColumnAccess, WithHeaderNormalizer,
+// WithMiddleware, WithSkipInvalidRows, and WithQuoteRecovery, so they are
+// rejected with an error. Validation is only done when the CSV data is parsed,
+// i.e., on cache hits, rows and asset references are not validated again.
+func LoadCached[T any](cache *Cache, fsys fs.FS, name string, opts ...Option) ([]T, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := cacheKey[T](data, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %q: %w", name, err)
+	}
+	cachePath := filepath.Join(cache.Dir, key+".gob")
+
+	if rows, err := loadCached[T](cachePath); err == nil {
+		return rows, nil
+	}
+
+	rows, err := loadRows[T](bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %q: %w", name, err)
+	}
+
+	if err := storeCached(cachePath, rows); err != nil {
+		return nil, fmt.Errorf("failed to store %q in cache: %w", name, err)
+	}
+
+	return rows, nil
+}
+
+// loadCached decodes the rows stored in the cache file `cachePath`.
+func loadCached[T any](cachePath string) ([]T, error) {
+	file, err := os.Open(cachePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rows []T
+	if err := gob.NewDecoder(file).Decode(&rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// storeCached encodes the rows into the cache file `cachePath`. The file is
+// written atomically, so concurrent loads never see a partially written file.
+func storeCached[T any](cachePath string, rows []T) error {
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(cachePath), filepath.Base(cachePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if err := gob.NewEncoder(file).Encode(rows); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), cachePath)
+}
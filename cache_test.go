@@ -0,0 +1,128 @@
+package csvstruct_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestLoadCached(t *testing.T) {
+	cache := &csvstruct.Cache{Dir: t.TempDir()}
+	fsys := fstest.MapFS{"prefabs.csv": &fstest.MapFile{Data: []byte(testData)}}
+
+	want := []Prefab{
+		{&Info{"Alex", "Fighter"}, &Attributes{100, 10}, nil},
+		{&Info{"Jayden", "Wizard"}, &Attributes{90, 20}, nil},
+		{&Info{"Mary", "Queen"}, nil, nil},
+		{&Info{"Player", ""}, nil, &Player{}},
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := csvstruct.LoadCached[Prefab](cache, fsys, "prefabs.csv")
+		if err != nil {
+			t.Fatalf("LoadCached() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("LoadCached() diff = %v", diff)
+		}
+
+		entries, err := os.ReadDir(cache.Dir)
+		if err != nil {
+			t.Fatalf("ReadDir() err = %v; want %v", err, nil)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("ReadDir() len = %d; want %d", len(entries), 1)
+		}
+	}
+
+	// Changing the data invalidates the cached table.
+	fsys["prefabs.csv"].Data = []byte("Info.Name\nAlex\n")
+
+	got, err := csvstruct.LoadCached[Prefab](cache, fsys, "prefabs.csv")
+	if err != nil {
+		t.Fatalf("LoadCached() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff([]Prefab{{&Info{"Alex", ""}, nil, nil}}, got); diff != "" {
+		t.Fatalf("LoadCached() diff = %v", diff)
+	}
+}
+
+func TestLoadCached_Options(t *testing.T) {
+	cache := &csvstruct.Cache{Dir: t.TempDir()}
+	fsys := fstest.MapFS{"prefabs.csv": &fstest.MapFile{Data: []byte("Info.Name,Attributes.HP,Attributes.Damage\nMary,,\n")}}
+
+	for _, opts := range [][]csvstruct.Option{nil, {csvstruct.WithEmptyNumbersAsZero()}, nil} {
+		want, err := csvstruct.LoadAll[Prefab](fsys, "prefabs.csv", opts...)
+		if err != nil {
+			t.Fatalf("LoadAll() err = %v; want %v", err, nil)
+		}
+
+		got, err := csvstruct.LoadCached[Prefab](cache, fsys, "prefabs.csv", opts...)
+		if err != nil {
+			t.Fatalf("LoadCached() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("LoadCached() diff = %v", diff)
+		}
+	}
+
+	entries, err := os.ReadDir(cache.Dir)
+	if err != nil {
+		t.Fatalf("ReadDir() err = %v; want %v", err, nil)
+	}
+	if len(entries) != 2 {
+		t.Errorf("ReadDir() len = %d; want %d", len(entries), 2)
+	}
+}
+
+func TestLoadCached_RegisteredCodecs(t *testing.T) {
+	cache := &csvstruct.Cache{Dir: t.TempDir()}
+	fsys := fstest.MapFS{"prefabs.csv": &fstest.MapFile{Data: []byte(testData)}}
+
+	for i, register := range []bool{false, true} {
+		if register {
+			// Registering a codec invalidates the cached tables. The name is
+			// unique, since codecs can't be unregistered.
+			csvstruct.RegisterNamedCodec(cache.Dir, colorCodec{})
+		}
+
+		if _, err := csvstruct.LoadCached[Prefab](cache, fsys, "prefabs.csv"); err != nil {
+			t.Fatalf("LoadCached() err = %v; want %v", err, nil)
+		}
+
+		entries, err := os.ReadDir(cache.Dir)
+		if err != nil {
+			t.Fatalf("ReadDir() err = %v; want %v", err, nil)
+		}
+		if len(entries) != i+1 {
+			t.Errorf("ReadDir() len = %d; want %d", len(entries), i+1)
+		}
+	}
+}
+
+func TestLoadCached_UncacheableOptions(t *testing.T) {
+	cache := &csvstruct.Cache{Dir: t.TempDir()}
+	fsys := fstest.MapFS{"prefabs.csv": &fstest.MapFile{Data: []byte(testData)}}
+
+	tests := []struct {
+		opt  csvstruct.Option
+		want string
+	}{
+		{csvstruct.WithHeaderNormalizer(strings.ToUpper), "WithHeaderNormalizer"},
+		{csvstruct.WithAssetFS(fstest.MapFS{}), "WithAssetFS"},
+	}
+
+	for _, test := range tests {
+		_, err := csvstruct.LoadCached[Prefab](cache, fsys, "prefabs.csv", test.opt)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("LoadCached() err = %v; want error containing %q", err, test.want)
+		}
+	}
+}
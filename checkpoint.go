@@ -0,0 +1,117 @@
+package csvstruct
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Checkpoint is the progress of a Reader, which can be used to resume reading
+// later, e.g., after the process restarts. It can be serialized, e.g., with
+// encoding/json.
+type Checkpoint struct {
+	// Offset in bytes of the next row to read.
+	Offset int64
+	// CSV header of the current table, or nil if the next row to read is a CSV
+	// header.
+	Header []string
+	// Units of the columns of Header, from the units row of the current table,
+	// or nil if there is none. Only used with the WithUnits option.
+	Units []string
+	// Fingerprint of the schema, i.e., the type `T`, the CSV header, and the
+	// units. It's empty if Header is nil.
+	Fingerprint string
+}
+
+// ErrSchemaMismatch is returned when data that was produced with one schema,
+// e.g., a checkpoint or an index, is used with an incompatible schema.
+var ErrSchemaMismatch = errors.New("schema mismatch")
+
+// SchemaFingerprint returns a stable hash of the compiled schema of the current
+// table, i.e., the description of the type `T`, including the names, types,
+// and tags of its fields, and the columns of the CSV header, including how
+// they map to the fields and their units, if any. It's empty if the CSV header of the current table
+// hasn't been read.
+//
+// Two readers with the same fingerprint decode the same data rows in the same
+// way, so the fingerprint can be stored with data derived from the CSV data,
+// e.g., checkpoints or indices, to detect early that the type or the CSV
+// header changed.
+func (r *Reader[T]) SchemaFingerprint() string {
+	if !r.hasDescriptors {
+		return ""
+	}
+	return r.schemaFingerprint()
+}
+
+// schemaFingerprint returns the fingerprint of the type `T` and the column
+// descriptors created from the CSV header, even if they are not yet in use.
+func (r *Reader[T]) schemaFingerprint() string {
+	hash := sha256.New()
+	writeTypeFingerprint(hash, reflect.TypeFor[T](), map[reflect.Type]bool{})
+	fmt.Fprintln(hash)
+	for _, descriptor := range r.colDescriptors {
+		fmt.Fprintf(hash, "%s %v %v %v %v %q %q %v\n", descriptor.qualName(), descriptor.typ, descriptor.ignored, descriptor.componentIndex, descriptor.fieldIndex, descriptor.mapKey, r.units[descriptor.qualName()], descriptor.unitScale)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Checkpoint returns the current progress of this reader. The checkpoint can be
+// passed to ResumeReader to resume reading from the next row.
+func (r *Reader[T]) Checkpoint() Checkpoint {
+	checkpoint := Checkpoint{Offset: r.baseOffset + r.reader.InputOffset()}
+	if r.hasDescriptors {
+		checkpoint.Header = append([]string(nil), r.header...)
+		checkpoint.Units = r.columnUnits()
+		checkpoint.Fingerprint = r.schemaFingerprint()
+	}
+	return checkpoint
+}
+
+// ResumeReader returns a new reader that resumes reading the CSV data in `rs`
+// from the given checkpoint, which was returned by Reader.Checkpoint on a
+// reader for the same CSV data. The type `T` is the schema that is used to
+// parse the data.
+//
+// The underlying CSV reader is created by csv.NewReader and therefore it uses
+// the default settings. Line numbers in errors are relative to the checkpoint.
+// With the WithUnits option, the units of the current table are restored from
+// the checkpoint rather than read again.
+//
+// Returns an error that wraps ErrSchemaMismatch if the type `T` is
+// incompatible with the schema that was used when the checkpoint was created.
+func ResumeReader[T any](rs io.ReadSeeker, checkpoint Checkpoint, opts ...Option) (*Reader[T], error) {
+	if _, err := rs.Seek(checkpoint.Offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	r := NewReader[T](csv.NewReader(rs), opts...)
+	r.baseOffset = checkpoint.Offset
+
+	if checkpoint.Header == nil {
+		return r, nil
+	}
+
+	if err := r.compileHeader(checkpoint.Header); err != nil {
+		return nil, err
+	}
+	if len(checkpoint.Units) > len(checkpoint.Header) {
+		return nil, fmt.Errorf("checkpoint has %d units but its CSV header has %d columns", len(checkpoint.Units), len(checkpoint.Header))
+	}
+	if r.options.units {
+		if err := r.applyUnits(checkpoint.Units); err != nil {
+			return nil, err
+		}
+	}
+
+	if fingerprint := r.schemaFingerprint(); fingerprint != checkpoint.Fingerprint {
+		return nil, fmt.Errorf("%w: checkpoint schema fingerprint %s does not match schema fingerprint %s of type %s", ErrSchemaMismatch, checkpoint.Fingerprint, fingerprint, reflect.TypeFor[T]().String())
+	}
+
+	r.hasDescriptors = true
+	return r, nil
+}
@@ -0,0 +1,153 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestResumeReader(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
+
+	var got Prefab
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	data, err := json.Marshal(reader.Checkpoint())
+	if err != nil {
+		t.Fatalf("json.Marshal() err = %v; want %v", err, nil)
+	}
+
+	var checkpoint csvstruct.Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		t.Fatalf("json.Unmarshal() err = %v; want %v", err, nil)
+	}
+
+	resumed, err := csvstruct.ResumeReader[Prefab](strings.NewReader(testData), checkpoint)
+	if err != nil {
+		t.Fatalf("ResumeReader() err = %v; want %v", err, nil)
+	}
+
+	want := Prefab{&Info{"Jayden", "Wizard"}, &Attributes{90, 20}, nil}
+	if err := resumed.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Read() diff = %v", diff)
+	}
+
+	// A checkpoint of a resumed reader is relative to the start of the data.
+	if err := resumed.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	resumed, err = csvstruct.ResumeReader[Prefab](strings.NewReader(testData), resumed.Checkpoint())
+	if err != nil {
+		t.Fatalf("ResumeReader() err = %v; want %v", err, nil)
+	}
+
+	want = Prefab{&Info{"Player", ""}, nil, &Player{}}
+	if err := resumed.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Read() diff = %v", diff)
+	}
+}
+
+type Seeded struct {
+	Info       *Info
+	Balance    *Balance `csv:",zero"`
+	Attributes *Attributes
+}
+
+const seededData = `Info.Name,Balance.HP,Attributes.HP
+Alex,50,10
+Mary,,
+`
+
+func TestResumeReader_Defaults(t *testing.T) {
+	opts := []csvstruct.Option{csvstruct.WithDefaultComponents("Attributes")}
+	reader := csvstruct.NewReader[Seeded](csv.NewReader(strings.NewReader(seededData)), opts...)
+
+	var got Seeded
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	resumed, err := csvstruct.ResumeReader[Seeded](strings.NewReader(seededData), reader.Checkpoint(), opts...)
+	if err != nil {
+		t.Fatalf("ResumeReader() err = %v; want %v", err, nil)
+	}
+
+	if err := resumed.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Seeded{&Info{Name: "Mary"}, &Balance{100, 1.5, 2 * time.Second, "common"}, &Attributes{}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+}
+
+func TestResumeReader_IncompatibleSchema(t *testing.T) {
+	type OtherInfo struct {
+		Name  int
+		Class string
+	}
+
+	type OtherPrefab struct {
+		Info       *OtherInfo
+		Attributes *Attributes
+		Player     *Player
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
+
+	var got Prefab
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	if _, err := csvstruct.ResumeReader[OtherPrefab](strings.NewReader(testData), reader.Checkpoint()); !errors.Is(err, csvstruct.ErrSchemaMismatch) {
+		t.Fatalf("ResumeReader() err = %v; want %v", err, csvstruct.ErrSchemaMismatch)
+	}
+}
+
+func TestReaderSchemaFingerprint(t *testing.T) {
+	fingerprint := func(data string) string {
+		reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)))
+		if got := reader.SchemaFingerprint(); got != "" {
+			t.Errorf("SchemaFingerprint() = %q before the CSV header; want %q", got, "")
+		}
+
+		var prefab Prefab
+		if err := reader.Read(&prefab); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+		return reader.SchemaFingerprint()
+	}
+
+	got := fingerprint(testData)
+	if want := fingerprint(testData); got != want {
+		t.Errorf("SchemaFingerprint() = %q; want %q", got, want)
+	}
+
+	// Different values don't change the fingerprint, but a different CSV header
+	// does.
+	if other := fingerprint(strings.Replace(testData, "Alex", "Zoe", 1)); got != other {
+		t.Errorf("SchemaFingerprint() = %q; want %q", other, got)
+	}
+	if other := fingerprint("Info.Class,Info.Name\nFighter,Alex\n"); got == other {
+		t.Errorf("SchemaFingerprint() = %q; want different fingerprint", other)
+	}
+}
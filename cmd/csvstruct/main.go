@@ -0,0 +1,370 @@
+// Command csvstruct converts and validates CSV data with the same semantics as
+// the csvstruct package, e.g., so that teammates without the Go types of the
+// tables can use them from the shell.
+//
+// Usage:
+//
+//	csvstruct convert [-schema file] [-from format] [-to format] [file]
+//	csvstruct validate -schema file [-from format] [file]
+//
+// The formats are 'csv', 'tsv', and 'json'. CSV and TSV data has a CSV header
+// of qualified names, e.g., 'MyComponent.MyField', and JSON data is an array of
+// objects with one property per component, whose value is an object with one
+// property per field, i.e., the same component mapping as the package.
+//
+// The data is decoded with csvstruct.DynamicReader as described by the JSON
+// Schema document given by '-schema', e.g., as written by
+// csvstruct.JSONSchemaFor. If there is no schema, convert infers one from the
+// data, where each column is an integer, a number, a boolean, or a string.
+//
+// If there is no file, the data is read from the standard input. Converted
+// data is written to the standard output.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jabolopes/csvstruct"
+)
+
+const usage = `usage:
+  csvstruct convert [-schema file] [-from format] [-to format] [file]
+  csvstruct validate -schema file [-from format] [file]
+
+The formats are csv, tsv, and json.
+`
+
+// table is CSV data decoded by a DynamicReader.
+type table struct {
+	// Columns of the CSV header, i.e., qualified names.
+	header []string
+	// Rows indexed by column name. See DynamicReader.Read.
+	rows []map[string]any
+}
+
+// comma returns the CSV separator of `format`, or an error if `format` is not
+// 'csv' or 'tsv'.
+func comma(format string) (rune, error) {
+	switch format {
+	case "csv":
+		return ',', nil
+	case "tsv":
+		return '\t', nil
+	}
+	return 0, fmt.Errorf("unknown format %q; want csv, tsv, or json", format)
+}
+
+// flatten adds the properties of the JSON object `object` to `record` indexed
+// by qualified name, i.e., the properties of nested objects are prefixed by the
+// name of their parent followed by a period.
+func flatten(prefix string, object map[string]any, record map[string]string) error {
+	for name, value := range object {
+		if len(prefix) > 0 {
+			name = prefix + "." + name
+		}
+
+		switch value := value.(type) {
+		case map[string]any:
+			if err := flatten(name, value, record); err != nil {
+				return err
+			}
+		case nil:
+			record[name] = ""
+		case string:
+			record[name] = value
+		case json.Number:
+			record[name] = value.String()
+		case bool:
+			record[name] = strconv.FormatBool(value)
+		default:
+			return fmt.Errorf("property %q is a %T; want an object, string, number, boolean, or null", name, value)
+		}
+	}
+	return nil
+}
+
+// jsonToCSV converts the JSON array of objects `data` to CSV data, whose CSV
+// header are the qualified names of the properties, sorted by name.
+func jsonToCSV(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var objects []map[string]any
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %v", err)
+	}
+
+	var header []string
+	records := make([]map[string]string, len(objects))
+	for i, object := range objects {
+		records[i] = map[string]string{}
+		if err := flatten("", object, records[i]); err != nil {
+			return nil, fmt.Errorf("object %d: %v", i+1, err)
+		}
+		for name := range records[i] {
+			if !slices.Contains(header, name) {
+				header = append(header, name)
+			}
+		}
+	}
+	slices.Sort(header)
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Write(header)
+	for _, record := range records {
+		row := make([]string, len(header))
+		for i, name := range header {
+			row[i] = record[name]
+		}
+		writer.Write(row)
+	}
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// inferType returns the JSON Schema type of the non-empty `cells`, i.e.,
+// 'integer', 'number', or 'boolean' if all of them parse as such, or 'string'
+// otherwise.
+func inferType(cells []string) string {
+	parses := func(parse func(string) error) bool {
+		for _, cell := range cells {
+			if len(cell) > 0 && parse(cell) != nil {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case len(cells) == 0:
+		return "string"
+	case parses(func(cell string) error { _, err := strconv.ParseInt(cell, 10, 64); return err }):
+		return "integer"
+	case parses(func(cell string) error { _, err := strconv.ParseFloat(cell, 64); return err }):
+		return "number"
+	case parses(func(cell string) error { _, err := strconv.ParseBool(cell); return err }):
+		return "boolean"
+	}
+	return "string"
+}
+
+// inferSchema returns a JSON Schema document whose properties are the columns
+// of the CSV header of `records`, with the types inferred from their cells.
+func inferSchema(records [][]string) ([]byte, error) {
+	if len(records) == 0 {
+		return nil, errors.New("failed to read CSV header: EOF")
+	}
+
+	type property struct {
+		Type string `json:"type"`
+	}
+	properties := map[string]property{}
+	for i, name := range records[0] {
+		var cells []string
+		for _, record := range records[1:] {
+			if i < len(record) && len(record[i]) > 0 {
+				cells = append(cells, record[i])
+			}
+		}
+		properties[name] = property{inferType(cells)}
+	}
+	return json.Marshal(map[string]any{"type": "object", "properties": properties})
+}
+
+// readTable decodes `data` in `format` with a DynamicReader as described by
+// `schema`, or by a schema inferred from the data if `schema` is nil.
+func readTable(data []byte, format string, schema []byte) (*table, error) {
+	separator := ','
+	if format == "json" {
+		var err error
+		if data, err = jsonToCSV(data); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if separator, err = comma(format); err != nil {
+			return nil, err
+		}
+	}
+
+	newReader := func() *csv.Reader {
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.Comma = separator
+		return reader
+	}
+
+	records, err := newReader().ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		if schema, err = inferSchema(records); err != nil {
+			return nil, err
+		}
+	}
+
+	reader, err := csvstruct.NewDynamicReader(newReader(), schema)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return &table{records[0], rows}, nil
+}
+
+// formatCell returns the CSV cell of the value `value` decoded by a
+// DynamicReader.
+func formatCell(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	return fmt.Sprint(value)
+}
+
+// nest returns `row` as a JSON object with one property per component, i.e.,
+// the inverse of flatten.
+func nest(row map[string]any) (map[string]any, error) {
+	object := map[string]any{}
+	for name, value := range row {
+		parent := object
+		path := strings.Split(name, ".")
+		for _, key := range path[:len(path)-1] {
+			child, ok := parent[key].(map[string]any)
+			if !ok {
+				if _, ok := parent[key]; ok {
+					return nil, fmt.Errorf("column %q is both a value and a component", key)
+				}
+				child = map[string]any{}
+				parent[key] = child
+			}
+			parent = child
+		}
+
+		key := path[len(path)-1]
+		if _, ok := parent[key]; ok {
+			return nil, fmt.Errorf("column %q is both a value and a component", name)
+		}
+		parent[key] = value
+	}
+	return object, nil
+}
+
+// writeTable writes `table` to `w` in `format`.
+func writeTable(w io.Writer, format string, table *table) error {
+	if format == "json" {
+		objects := make([]map[string]any, len(table.rows))
+		for i, row := range table.rows {
+			var err error
+			if objects[i], err = nest(row); err != nil {
+				return fmt.Errorf("row %d: %v", i+1, err)
+			}
+		}
+		data, err := json.MarshalIndent(objects, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	separator, err := comma(format)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma = separator
+	writer.Write(table.header)
+	for _, row := range table.rows {
+		record := make([]string, len(table.header))
+		for i, name := range table.header {
+			record[i] = formatCell(row[name])
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// run runs the command with the command line arguments `args`, excluding the
+// program name, and returns its exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || (args[0] != "convert" && args[0] != "validate") {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	command := args[0]
+
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	schemaFile := flags.String("schema", "", "JSON Schema document of the data")
+	from := flags.String("from", "csv", "format of the input data")
+	to := flags.String("to", "json", "format of the output data")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if flags.NArg() > 1 || (command == "validate" && len(*schemaFile) == 0) {
+		flags.Usage()
+		return 2
+	}
+
+	err := func() error {
+		var schema []byte
+		if len(*schemaFile) > 0 {
+			var err error
+			if schema, err = os.ReadFile(*schemaFile); err != nil {
+				return err
+			}
+		}
+
+		var data []byte
+		var err error
+		if flags.NArg() == 1 {
+			data, err = os.ReadFile(flags.Arg(0))
+		} else {
+			data, err = io.ReadAll(stdin)
+		}
+		if err != nil {
+			return err
+		}
+
+		table, err := readTable(data, *from, schema)
+		if err != nil {
+			return err
+		}
+		if command == "validate" {
+			return nil
+		}
+		return writeTable(stdout, *to, table)
+	}()
+	if err != nil {
+		fmt.Fprintf(stderr, "csvstruct %s: %v\n", command, err)
+		return 1
+	}
+	return 0
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const prefabData = `Info.Name,Attributes.HP,Attributes.Speed,Player
+Alex,100,1.5,true
+Jayden,,2,false
+`
+
+const prefabJSON = `[
+  {
+    "Attributes": {
+      "HP": 100,
+      "Speed": 1.5
+    },
+    "Info": {
+      "Name": "Alex"
+    },
+    "Player": true
+  },
+  {
+    "Attributes": {
+      "Speed": 2
+    },
+    "Info": {
+      "Name": "Jayden"
+    },
+    "Player": false
+  }
+]
+`
+
+const prefabSchema = `{
+  "type": "object",
+  "properties": {
+    "Info.Name": {"type": "string", "minLength": 1},
+    "Attributes.HP": {"type": "integer", "minimum": 0, "maximum": 100},
+    "Attributes.Speed": {"type": "number"},
+    "Player": {"type": "boolean"}
+  },
+  "required": ["Info.Name"]
+}`
+
+func writeSchema(t *testing.T) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "prefab.schema.json")
+	if err := os.WriteFile(file, []byte(prefabSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestRun_Convert(t *testing.T) {
+	schema := writeSchema(t)
+
+	tests := []struct {
+		name string
+		args []string
+		in   string
+		want string
+	}{
+		{"CSVToJSON", []string{"convert"}, prefabData, prefabJSON},
+		{"CSVToJSONWithSchema", []string{"convert", "-schema", schema}, prefabData, prefabJSON},
+		{"JSONToCSV", []string{"convert", "-from", "json", "-to", "csv"}, prefabJSON, "Attributes.HP,Attributes.Speed,Info.Name,Player\n100,1.5,Alex,true\n,2,Jayden,false\n"},
+		{"CSVToTSV", []string{"convert", "-to", "tsv"}, prefabData, strings.ReplaceAll(prefabData, ",", "\t")},
+		{"TSVToCSV", []string{"convert", "-from", "tsv", "-to", "csv"}, strings.ReplaceAll(prefabData, ",", "\t"), prefabData},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := run(test.args, strings.NewReader(test.in), &stdout, &stderr); got != 0 {
+				t.Fatalf("run(%q) = %d; want 0 (stderr: %s)", test.args, got, stderr.String())
+			}
+
+			if diff := cmp.Diff(test.want, stdout.String()); diff != "" {
+				t.Errorf("run(%q) output = %v", test.args, diff)
+			}
+		})
+	}
+}
+
+func TestRun_Validate(t *testing.T) {
+	schema := writeSchema(t)
+
+	tests := []struct {
+		name     string
+		in       string
+		wantCode int
+		wantErr  string
+	}{
+		{"Valid", prefabData, 0, ""},
+		{"OutOfRange", "Info.Name,Attributes.HP\nAlex,200\n", 1, "csvstruct validate: line 2, column 2 (Attributes.HP): value 200 is greater than the maximum 100\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args := []string{"validate", "-schema", schema}
+			var stdout, stderr bytes.Buffer
+			if got := run(args, strings.NewReader(test.in), &stdout, &stderr); got != test.wantCode {
+				t.Errorf("run(%q) = %d; want %d", args, got, test.wantCode)
+			}
+
+			if diff := cmp.Diff(test.wantErr, stderr.String()); diff != "" {
+				t.Errorf("run(%q) stderr = %v", args, diff)
+			}
+		})
+	}
+}
+
+func TestRun_Usage(t *testing.T) {
+	for _, args := range [][]string{nil, {"format"}, {"validate"}} {
+		var stdout, stderr bytes.Buffer
+		if got, want := run(args, strings.NewReader(""), &stdout, &stderr), 2; got != want {
+			t.Errorf("run(%q) = %d; want %d", args, got, want)
+		}
+	}
+}
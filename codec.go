@@ -0,0 +1,89 @@
+package csvstruct
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Codec converts between CSV cells and values of a Go type. Codecs allow
+// component fields to have domain types, e.g., UUIDs, colors, vectors, or
+// enums, that the package doesn't know about.
+//
+// Codecs are registered either globally with RegisterCodec or per reader and
+// writer with the WithCodec and WithWriteCodec options.
+type Codec interface {
+	// Decode parses the non-empty `cell` into `dst`, which is a settable value
+	// of the codec's type.
+	Decode(cell string, dst reflect.Value) error
+	// Encode formats `src`, which is a value of the codec's type, as a cell.
+	Encode(src reflect.Value) (string, error)
+}
+
+var (
+	// Protects globalCodecs.
+	globalCodecsMu sync.RWMutex
+	// Codecs registered with RegisterCodec, indexed by type.
+	globalCodecs = map[reflect.Type]Codec{
+		diceType: diceCodec{},
+	}
+)
+
+// RegisterCodec registers a codec for the given type for all readers and
+// writers. Codecs given with WithCodec or WithWriteCodec take precedence.
+//
+// This is thread-safe, but readers and writers look up codecs when they are
+// created or when they read a CSV header, so codecs should be registered
+// beforehand, e.g., in an init function.
+func RegisterCodec(typ reflect.Type, codec Codec) {
+	globalCodecsMu.Lock()
+	defer globalCodecsMu.Unlock()
+	globalCodecs[typ] = codec
+}
+
+// lookupCodec returns the codec for the given type, giving precedence to the
+// codecs in `codecs` over the global codecs, and to the global codecs over
+// encoding.TextUnmarshaler, or nil if there is none.
+func lookupCodec(codecs map[reflect.Type]Codec, typ reflect.Type) Codec {
+	if codec, ok := codecs[typ]; ok {
+		return codec
+	}
+
+	globalCodecsMu.RLock()
+	defer globalCodecsMu.RUnlock()
+	if codec, ok := globalCodecs[typ]; ok {
+		return codec
+	}
+
+	if isTextType(typ) {
+		return textCodec{}
+	}
+	return nil
+}
+
+var (
+	// Protects namedCodecs.
+	namedCodecsMu sync.RWMutex
+	// Codecs registered with RegisterNamedCodec, indexed by name.
+	namedCodecs = map[string]Codec{}
+)
+
+// RegisterNamedCodec registers a codec under the given name, so that it can be
+// referenced by name, e.g., by the converters of a Mapping.
+//
+// This is thread-safe, but readers look up named codecs when they read a CSV
+// header, so codecs should be registered beforehand, e.g., in an init
+// function.
+func RegisterNamedCodec(name string, codec Codec) {
+	namedCodecsMu.Lock()
+	defer namedCodecsMu.Unlock()
+	namedCodecs[name] = codec
+}
+
+// lookupNamedCodec returns the codec registered under the given name, and
+// whether it exists.
+func lookupNamedCodec(name string) (Codec, bool) {
+	namedCodecsMu.RLock()
+	defer namedCodecsMu.RUnlock()
+	codec, ok := namedCodecs[name]
+	return codec, ok
+}
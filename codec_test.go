@@ -0,0 +1,104 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Color struct {
+	R, G, B uint8
+}
+
+type colorCodec struct{}
+
+func (colorCodec) Decode(cell string, dst reflect.Value) error {
+	var c Color
+	if _, err := fmt.Sscanf(cell, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+		return fmt.Errorf("invalid color %q", cell)
+	}
+	dst.Set(reflect.ValueOf(c))
+	return nil
+}
+
+func (colorCodec) Encode(src reflect.Value) (string, error) {
+	c := src.Interface().(Color)
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B), nil
+}
+
+type ItemID string
+
+type itemIDCodec struct{}
+
+func (itemIDCodec) Decode(cell string, dst reflect.Value) error {
+	dst.SetString("item:" + cell)
+	return nil
+}
+
+func (itemIDCodec) Encode(src reflect.Value) (string, error) {
+	return strings.TrimPrefix(src.String(), "item:"), nil
+}
+
+func init() {
+	csvstruct.RegisterCodec(reflect.TypeFor[ItemID](), itemIDCodec{})
+}
+
+type Banner struct {
+	Color Color
+	Item  ItemID
+}
+
+type Flag struct {
+	Banner *Banner
+}
+
+func TestCodec(t *testing.T) {
+	const data = `Banner.Color,Banner.Item
+#ff8000,sword
+`
+
+	want := Flag{&Banner{Color{0xff, 0x80, 0x00}, "item:sword"}}
+
+	reader := csvstruct.NewReader[Flag](csv.NewReader(strings.NewReader(data)), csvstruct.WithCodec(reflect.TypeFor[Color](), colorCodec{}))
+
+	var got Flag
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Read() diff = %v", diff)
+	}
+
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Flag](csv.NewWriter(&buf), csvstruct.WithWriteCodec(reflect.TypeFor[Color](), colorCodec{}))
+	if err := writer.Write(got); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(data, buf.String()); diff != "" {
+		t.Fatalf("Write() diff = %v", diff)
+	}
+}
+
+func TestCodec_Error(t *testing.T) {
+	const data = `Banner.Color
+red
+`
+
+	reader := csvstruct.NewReader[Flag](csv.NewReader(strings.NewReader(data)), csvstruct.WithCodec(reflect.TypeFor[Color](), colorCodec{}))
+
+	var got Flag
+	err := reader.Read(&got)
+	if err == nil || !strings.Contains(err.Error(), `line 2, column 1 (Banner.Color): invalid color "red"`) {
+		t.Fatalf("Read() err = %v; want invalid color error", err)
+	}
+}
@@ -0,0 +1,39 @@
+package csvstruct
+
+import (
+	"errors"
+	"reflect"
+)
+
+// converterCodec is the codec of a function given to WithConverter. It only
+// decodes cells.
+type converterCodec func(string) (any, error)
+
+func (c converterCodec) Decode(cell string, dst reflect.Value) error {
+	value, err := c(cell)
+	if err != nil {
+		return err
+	}
+
+	v, err := convertValue(value, dst.Type())
+	if err != nil {
+		return err
+	}
+	dst.Set(v)
+	return nil
+}
+
+func (c converterCodec) Encode(src reflect.Value) (string, error) {
+	return "", errors.New("converters given with WithConverter don't encode values")
+}
+
+// WithConverter uses `convert` to decode the non-empty cells of fields of the
+// given type, e.g., domain types like colors or vectors that don't implement
+// encoding.TextUnmarshaler. The value returned by `convert` must be assignable
+// or convertible to `typ`.
+//
+// This is a shorthand for WithCodec when only decoding is needed, and it
+// likewise takes precedence over codecs registered with RegisterCodec.
+func WithConverter(typ reflect.Type, convert func(string) (any, error)) Option {
+	return WithCodec(typ, converterCodec(convert))
+}
@@ -0,0 +1,70 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Vec2 struct {
+	X, Y float64
+}
+
+type Transform struct {
+	Position Vec2
+	Layer    ItemID
+}
+
+type Body struct {
+	Transform *Transform
+}
+
+func parseVec2(cell string) (any, error) {
+	var v Vec2
+	if _, err := fmt.Sscanf(cell, "%g;%g", &v.X, &v.Y); err != nil {
+		return nil, fmt.Errorf("invalid vector %q", cell)
+	}
+	return v, nil
+}
+
+func TestConverter(t *testing.T) {
+	const data = `Transform.Position,Transform.Layer
+1.5;-2,ground
+`
+
+	reader := csvstruct.NewReader[Body](csv.NewReader(strings.NewReader(data)),
+		csvstruct.WithConverter(reflect.TypeFor[Vec2](), parseVec2),
+		// Takes precedence over the codec registered with RegisterCodec.
+		csvstruct.WithConverter(reflect.TypeFor[ItemID](), func(cell string) (any, error) {
+			return strings.ToUpper(cell), nil
+		}))
+
+	var got Body
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Body{&Transform{Vec2{1.5, -2}, "GROUND"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+}
+
+func TestConverter_Error(t *testing.T) {
+	const data = `Transform.Position
+north
+`
+
+	reader := csvstruct.NewReader[Body](csv.NewReader(strings.NewReader(data)), csvstruct.WithConverter(reflect.TypeFor[Vec2](), parseVec2))
+
+	var got Body
+	want := `line 2, column 1 (Transform.Position): invalid vector "north"`
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
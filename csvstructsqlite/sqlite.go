@@ -0,0 +1,64 @@
+// Package csvstructsqlite loads data decoded by csvstruct into SQLite
+// databases, so that it can be queried with SQL.
+//
+// This package uses database/sql and it doesn't depend on a SQLite driver, so
+// the caller opens the database with a driver of their choice, e.g.:
+//
+//	db, err := sql.Open("sqlite3", "data.db")
+//	...
+//	prefabs, err := csvstruct.LoadAll[Prefab](os.DirFS("data"), "prefabs.csv")
+//	...
+//	err = csvstructsqlite.Load(ctx, db, "prefabs", prefabs)
+package csvstructsqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jabolopes/csvstruct"
+)
+
+// maxVariables is the maximum number of arguments of a SQL statement, which is
+// the default limit of SQLite versions before 3.32.0.
+const maxVariables = 999
+
+// Load creates the table `table` in `db`, if it doesn't exist, with the
+// columns of `T`, and inserts `rows` into it in a single transaction.
+//
+// The columns are the qualified names of the columns written by
+// csvstruct.Writer, e.g., "Info.Name", and they can be selected with the same
+// options, e.g., csvstruct.WithWriteComponents. See csvstruct.CreateTable and
+// csvstruct.InsertBatches.
+func Load[T any](ctx context.Context, db *sql.DB, table string, rows []T, opts ...csvstruct.WriterOption) error {
+	createTable, err := csvstruct.CreateTable[T](table, opts...)
+	if err != nil {
+		return err
+	}
+
+	numColumns := len(csvstruct.NewWriter[T](nil, opts...).Header())
+	batchSize := maxVariables / max(numColumns, 1)
+
+	batches, err := csvstruct.InsertBatches(table, rows, batchSize, csvstruct.QuestionPlaceholders, opts...)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, createTable); err != nil {
+		return fmt.Errorf("failed to create table %q: %w", table, err)
+	}
+
+	for _, batch := range batches {
+		if _, err := tx.ExecContext(ctx, batch.Query, batch.Args...); err != nil {
+			return fmt.Errorf("failed to insert into table %q: %w", table, err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,103 @@
+package csvstructsqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+	"github.com/jabolopes/csvstruct/csvstructsqlite"
+)
+
+// recorder is a fake database/sql driver that records the executed statements.
+type recorder struct {
+	statements []string
+}
+
+func (r *recorder) Open(name string) (driver.Conn, error) { return &conn{r}, nil }
+
+type conn struct{ recorder *recorder }
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("Prepare() not supported")
+}
+func (c *conn) Close() error              { return nil }
+func (c *conn) Begin() (driver.Tx, error) { return c, nil }
+func (c *conn) Commit() error {
+	c.recorder.statements = append(c.recorder.statements, "COMMIT")
+	return nil
+}
+func (c *conn) Rollback() error { return nil }
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	statement := query
+	for _, arg := range args {
+		statement += fmt.Sprintf(" %v", arg.Value)
+	}
+	c.recorder.statements = append(c.recorder.statements, statement)
+	return driver.RowsAffected(0), nil
+}
+
+type Info struct {
+	Name string
+}
+
+type Attributes struct {
+	HP int
+}
+
+type Prefab struct {
+	Info       *Info
+	Attributes *Attributes
+}
+
+func TestLoad(t *testing.T) {
+	recorder := &recorder{}
+	sql.Register("csvstructsqlite-recorder", recorder)
+
+	db, err := sql.Open("csvstructsqlite-recorder", "")
+	if err != nil {
+		t.Fatalf("Open() err = %v; want %v", err, nil)
+	}
+	defer db.Close()
+
+	prefabs := []Prefab{
+		{&Info{"Alex"}, &Attributes{100}},
+		{&Info{"Mary"}, nil},
+	}
+
+	if err := csvstructsqlite.Load(context.Background(), db, "prefabs", prefabs); err != nil {
+		t.Fatalf("Load() err = %v; want %v", err, nil)
+	}
+
+	want := []string{
+		`CREATE TABLE IF NOT EXISTS "prefabs" ("Info.Name" TEXT, "Attributes.HP" INTEGER)`,
+		`INSERT INTO "prefabs" ("Info.Name", "Attributes.HP") VALUES (?, ?), (?, ?) Alex 100 Mary <nil>`,
+		"COMMIT",
+	}
+
+	if diff := cmp.Diff(want, recorder.statements); diff != "" {
+		t.Errorf("Load() statements diff = %v", diff)
+	}
+}
+
+func TestLoad_InvalidOptions(t *testing.T) {
+	recorder := &recorder{}
+	sql.Register("csvstructsqlite-recorder-invalid", recorder)
+
+	db, err := sql.Open("csvstructsqlite-recorder-invalid", "")
+	if err != nil {
+		t.Fatalf("Open() err = %v; want %v", err, nil)
+	}
+	defer db.Close()
+
+	if err := csvstructsqlite.Load(context.Background(), db, "prefabs", []Prefab{}, csvstruct.WithWriteComponents("Inventory")); err == nil {
+		t.Fatalf("Load() err = %v; want error", err)
+	}
+	if len(recorder.statements) > 0 {
+		t.Errorf("Load() statements = %v; want none", recorder.statements)
+	}
+}
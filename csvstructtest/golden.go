@@ -0,0 +1,81 @@
+package csvstructtest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+// update is set by the -csvstructtest.update flag, e.g., 'go test
+// ./... -args -csvstructtest.update', to rewrite golden fixtures.
+var update = flag.Bool("csvstructtest.update", false, "rewrite golden CSV fixtures with the actual rows")
+
+// Load reads all the rows of the first table of the CSV fixture at `path`,
+// e.g., 'testdata/units.csv', and fails the test if the fixture can't be read
+// or decoded.
+func Load[T any](t testing.TB, path string, opts ...csvstruct.Option) []T {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open fixture: %v", err)
+		return nil
+	}
+	defer file.Close()
+
+	rows, err := csvstruct.NewReader[T](csv.NewReader(file), opts...).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to load fixture %s: %v", path, err)
+		return nil
+	}
+	return rows
+}
+
+// Golden compares `got` with the rows of the golden CSV fixture at `path`, and
+// reports an error with a field-level diff for each row that doesn't match, as
+// well as missing and unexpected rows.
+//
+// When the test runs with the -csvstructtest.update flag, the fixture is
+// rewritten with `got` instead, using a csvstruct.Writer.
+func Golden[T any](t testing.TB, path string, got []T, opts ...csvstruct.Option) {
+	t.Helper()
+
+	if *update {
+		var buf bytes.Buffer
+		writer := csvstruct.NewWriter[T](csv.NewWriter(&buf))
+		for _, row := range got {
+			if err := writer.Write(row); err != nil {
+				t.Fatalf("Write() err = %v; want %v", err, nil)
+				return
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatalf("Flush() err = %v; want %v", err, nil)
+			return
+		}
+
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("failed to update fixture: %v", err)
+		}
+		return
+	}
+
+	want := Load[T](t, path, opts...)
+	for i := 0; i < max(len(want), len(got)); i++ {
+		switch {
+		case i >= len(got):
+			t.Errorf("%s: row %d: missing row %+v", path, i, want[i])
+		case i >= len(want):
+			t.Errorf("%s: row %d: unexpected row %+v", path, i, got[i])
+		default:
+			if diff := cmp.Diff(want[i], got[i]); diff != "" {
+				t.Errorf("%s: row %d: golden diff (-want +got):\n%s", path, i, diff)
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package csvstructtest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+	"github.com/jabolopes/csvstruct/csvstructtest"
+)
+
+var goldenPrefabs = []Prefab{
+	{&Info{"Alex", "Fighter"}, &Attributes{100, csvstruct.Dice{Count: 2, Sides: 6, Modifier: 1}}, nil},
+	{&Info{"Mary", "Queen"}, nil, nil},
+	{&Info{"Player", ""}, nil, &Player{}},
+}
+
+func TestLoad(t *testing.T) {
+	got := csvstructtest.Load[Prefab](t, "testdata/prefabs.csv")
+
+	if diff := cmp.Diff(goldenPrefabs, got); diff != "" {
+		t.Errorf("Load() diff = %v", diff)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	csvstructtest.Golden(t, "testdata/prefabs.csv", goldenPrefabs)
+}
+
+func TestGolden_Mismatch(t *testing.T) {
+	r := &recorder{TB: t}
+
+	got := append([]Prefab{
+		{&Info{"Alex", "Wizard"}, goldenPrefabs[0].Attributes, nil},
+	}, goldenPrefabs[1:]...)
+	got = append(got, Prefab{Info: &Info{"Zoe", "Rogue"}})
+
+	csvstructtest.Golden(r, "testdata/prefabs.csv", got)
+
+	if len(r.errors) != 2 || !strings.Contains(r.errors[0], "row 0: golden diff") || !strings.Contains(r.errors[1], "row 3: unexpected row") {
+		t.Fatalf("Golden() errors = %v; want errors for rows 0 and 3", r.errors)
+	}
+}
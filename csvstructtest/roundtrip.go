@@ -0,0 +1,56 @@
+// Package csvstructtest provides helpers to test code that uses csvstruct.
+package csvstructtest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+// RoundTrip writes `rows` with a csvstruct.Writer, reads them back with a
+// csvstruct.Reader, and reports an error for each row that doesn't round trip,
+// including a field-level diff. If `rows` is empty, only the CSV header is
+// written and read back.
+//
+// This is useful to verify that custom types and converters are written in a
+// format that can be parsed back.
+func RoundTrip[T any](t testing.TB, rows []T) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := csvstruct.NewWriter[T](csv.NewWriter(&buf))
+	// The CSV header is written even if there are no rows, so that they can
+	// be read back.
+	if err := writer.WriteHeader(); err != nil {
+		t.Fatalf("WriteHeader() err = %v; want %v", err, nil)
+	}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	reader := csvstruct.NewReader[T](csv.NewReader(&buf))
+	for i, want := range rows {
+		var got T
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("row %d: Read() err = %v; want %v", i, err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("row %d: round trip diff (-want +got):\n%s", i, diff)
+		}
+	}
+
+	var got T
+	if err := reader.Read(&got); err != io.EOF {
+		t.Errorf("Read() err = %v; want %v", err, io.EOF)
+	}
+}
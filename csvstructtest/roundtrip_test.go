@@ -0,0 +1,77 @@
+package csvstructtest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jabolopes/csvstruct"
+	"github.com/jabolopes/csvstruct/csvstructtest"
+)
+
+type Info struct {
+	Name  string
+	Class string
+}
+
+type Attributes struct {
+	HP     int
+	Damage csvstruct.Dice
+}
+
+type Player struct{}
+
+type Prefab struct {
+	Info       *Info
+	Attributes *Attributes
+	Player     *Player
+}
+
+// recorder is a testing.TB that records errors instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func TestRoundTrip(t *testing.T) {
+	csvstructtest.RoundTrip(t, []Prefab{
+		{&Info{"Alex", "Fighter"}, &Attributes{100, csvstruct.Dice{Count: 2, Sides: 6, Modifier: 1}}, nil},
+		{&Info{"Mary", "Queen"}, nil, nil},
+		{&Info{"Player", ""}, nil, &Player{}},
+	})
+}
+
+func TestRoundTrip_Mismatch(t *testing.T) {
+	r := &recorder{TB: t}
+
+	// An info with only empty strings is written as empty cells, which are
+	// read back as a nil component.
+	csvstructtest.RoundTrip(r, []Prefab{
+		{&Info{"Alex", "Fighter"}, nil, nil},
+		{&Info{}, nil, &Player{}},
+	})
+
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "row 1: round trip diff") {
+		t.Fatalf("RoundTrip() errors = %v; want 1 error for row 1", r.errors)
+	}
+}
+
+func TestRoundTrip_Empty(t *testing.T) {
+	r := &recorder{TB: t}
+
+	csvstructtest.RoundTrip(r, []Prefab{})
+
+	if len(r.errors) != 0 {
+		t.Fatalf("RoundTrip() errors = %v; want none", r.errors)
+	}
+}
@@ -0,0 +1,41 @@
+package csvstruct
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReadDeadliner is a source of CSV data that supports read deadlines, e.g.,
+// net.Conn or os.File.
+type ReadDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// setRowDeadline sets the read deadline of the source given with
+// WithRowTimeout, if any, before reading a row.
+func (r *Reader[T]) setRowDeadline() error {
+	if r.options.deadlineSource == nil {
+		return nil
+	}
+
+	if err := r.options.deadlineSource.SetReadDeadline(time.Now().Add(r.options.rowTimeout)); err != nil {
+		return fmt.Errorf("failed to set read deadline: %w", err)
+	}
+	return nil
+}
+
+// WithRowTimeout fails reading if a row, including the CSV header, isn't read
+// within `timeout`, e.g., so that a stalled network stream doesn't block the
+// reader indefinitely. The deadline is set on `src`, which must be the source
+// of the CSV data of the underlying CSV reader, before each row is read.
+//
+// When the deadline is exceeded, Read returns an error that wraps
+// os.ErrDeadlineExceeded, and the table fails, like with other failures to
+// read the CSV data. This also bounds each row read by Reader.ReadToChan,
+// which otherwise only checks its context between rows.
+func WithRowTimeout(src ReadDeadliner, timeout time.Duration) Option {
+	return func(o *options) {
+		o.deadlineSource = src
+		o.rowTimeout = timeout
+	}
+}
@@ -0,0 +1,39 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderRowTimeout(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	// The upstream stalls after the first data row.
+	go io.WriteString(server, "Info.Name,Attributes.HP\nAlex,100\n")
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(client), csvstruct.WithRowTimeout(client, 50*time.Millisecond))
+
+	var got Prefab
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Prefab{Info: &Info{Name: "Alex"}, Attributes: &Attributes{HP: 100}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+
+	if err := reader.Read(&got); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Read() err = %v; want %v", err, os.ErrDeadlineExceeded)
+	}
+}
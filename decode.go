@@ -0,0 +1,86 @@
+package csvstruct
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// allocElem returns the struct pointed to by `value` if it's a pointer,
+// allocating it if it's nil, or `value` otherwise.
+func allocElem(value reflect.Value) reflect.Value {
+	if value.Kind() != reflect.Pointer {
+		return value
+	}
+	if value.IsNil() {
+		value.Set(reflect.New(value.Type().Elem()))
+	}
+	return value.Elem()
+}
+
+// allocFieldByIndex returns the nested field of `value` with the given index,
+// like reflect.Value.FieldByIndex, but it allocates the nil pointers to
+// embedded structs along the way.
+func allocFieldByIndex(value reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 {
+			value = allocElem(value)
+		}
+		value = value.Field(x)
+	}
+	return value
+}
+
+// convertValue converts a value decoded from a cell, e.g., an int64 for any
+// integer field, to the type `typ` of the field.
+func convertValue(value interface{}, typ reflect.Type) (reflect.Value, error) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return reflect.Zero(typ), nil
+	}
+	if v.Type().AssignableTo(typ) {
+		return v, nil
+	}
+
+	zero := reflect.Zero(typ)
+	sameKind := v.Kind() == typ.Kind() || v.CanInt() && zero.CanInt() || v.CanUint() && zero.CanUint() || v.CanFloat() && zero.CanFloat()
+	if sameKind && v.Type().ConvertibleTo(typ) {
+		return v.Convert(typ), nil
+	}
+	return reflect.Value{}, fmt.Errorf("value of type %s can't be assigned to a field of type %s", v.Type().String(), typ.String())
+}
+
+// setField sets the field described by the descriptor in `root`, which is the
+// value of type `T` being decoded, to `value`, which was decoded from a cell.
+// The component of the field is allocated if it's nil, even when the column
+// only marks the presence of the component, and the slice of a repeated
+// component is grown to include the element of the column.
+func (d *colDescriptor) setField(root reflect.Value, value interface{}) error {
+	component := allocFieldByIndex(root, d.componentIndex)
+	if d.isRepeated {
+		component = allocRepeatedElem(component, d.repeatedIndex)
+	}
+	component = allocElem(component)
+	if len(d.fieldIndex) == 0 {
+		return nil
+	}
+
+	field := allocFieldByIndex(component, d.fieldIndex)
+	if len(d.mapField) == 0 {
+		converted, err := convertValue(value, field.Type())
+		if err != nil {
+			return err
+		}
+		field.Set(converted)
+		return nil
+	}
+
+	converted, err := convertValue(value, field.Type().Elem())
+	if err != nil {
+		return err
+	}
+	if field.IsNil() {
+		field.Set(reflect.MakeMap(field.Type()))
+	}
+	field.SetMapIndex(reflect.ValueOf(d.mapKey).Convert(field.Type().Key()), converted)
+	return nil
+}
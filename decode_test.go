@@ -0,0 +1,68 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Rarity string
+
+type Level uint8
+
+type Base struct {
+	ID string
+}
+
+type Gear struct {
+	*Base
+	Rarity Rarity
+	Level  Level
+	Weight float32
+}
+
+type Loadout struct {
+	Gear Gear
+}
+
+func TestReaderDecode(t *testing.T) {
+	const data = `Gear.ID,Gear.Rarity,Gear.Level,Gear.Weight
+sword,epic,12,3.5
+,,,
+`
+
+	want := []Loadout{
+		{Gear{&Base{"sword"}, "epic", 12, 3.5}},
+		{},
+	}
+
+	reader := csvstruct.NewReader[Loadout](csv.NewReader(strings.NewReader(data)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func BenchmarkReader(b *testing.B) {
+	var data strings.Builder
+	data.WriteString("Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player\n")
+	for i := 0; i < 1000; i++ {
+		data.WriteString("Alex,Fighter,100,10,\n")
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data.String())))
+		if _, err := reader.ReadAll(); err != nil {
+			b.Fatalf("ReadAll() err = %v; want %v", err, nil)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package csvstruct
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// resolveDefaultComponents returns the indices of the fields of `T` of the
+// components that are allocated even when all their cells are empty, i.e., the
+// components given with WithDefaultComponents and the components tagged with
+// `csv:",zero"`, except the components tagged with `csv:",nil"`.
+func resolveDefaultComponents[T any](names []string) ([]int, error) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("type %s is not a struct", typ.String())
+	}
+
+	var indices []int
+	for _, name := range names {
+		field, ok := typ.FieldByName(name)
+		if !ok || !field.IsExported() || len(field.Index) != 1 {
+			return nil, fmt.Errorf("type %s does not have a field %q", typ.String(), name)
+		}
+		if _, ok := componentStruct(field.Type); !ok {
+			return nil, fmt.Errorf("field %q of type %s is not a component; want a struct or a pointer to a struct", name, typ.String())
+		}
+		indices = append(indices, field.Index[0])
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		zero, isNil := hasTagOption(field, "zero"), hasTagOption(field, "nil")
+		if !zero && !isNil {
+			continue
+		}
+
+		if field.Type.Kind() != reflect.Pointer || field.Type.Elem().Kind() != reflect.Struct {
+			return nil, fmt.Errorf("field %q of type %s has a csv tag with a zero or nil option but it's not a pointer to a struct", field.Name, typ.String())
+		}
+		if zero && isNil {
+			return nil, fmt.Errorf("field %q of type %s has a csv tag with both zero and nil options", field.Name, typ.String())
+		}
+
+		if zero {
+			indices = append(indices, i)
+		} else {
+			indices = slices.DeleteFunc(indices, func(index int) bool {
+				return index == i
+			})
+		}
+	}
+
+	return indices, nil
+}
+
+// allocateDefaultComponents allocates the components given with
+// WithDefaultComponents or tagged with `csv:",zero"` that are nil in `t`.
+func (r *Reader[T]) allocateDefaultComponents(t *T) {
+	value := reflect.ValueOf(t).Elem()
+	for _, index := range r.defaultComponents {
+		field := value.Field(index)
+		if r.options.slabSize > 0 {
+			r.slabAllocator().allocComponent(field)
+		} else if field.Kind() == reflect.Pointer && field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+	}
+}
+
+// fieldDefault is the default value of a component field, given by the
+// `default` option of its `csvstruct` tag.
+type fieldDefault struct {
+	// Index of the component field in `T`.
+	componentIndex int
+	// Index of the field in the component.
+	fieldIndex []int
+	// Default value of the field.
+	value reflect.Value
+	// Columns of the current CSV header that decode into the field.
+	columns []int
+}
+
+// parseDefault parses the default value `s` of a field of type `typ`. Bools
+// are parsed with `parseBool`, e.g., the reader's options.parseBool, or with
+// the default true and false values if it's nil.
+func parseDefault(codecs map[reflect.Type]Codec, parseBool func(string) (bool, error), typ reflect.Type, s string) (reflect.Value, error) {
+	if parseBool == nil {
+		parseBool = (&options{}).parseBool
+	}
+
+	value := reflect.New(typ).Elem()
+	if codec := lookupCodec(codecs, typ); codec != nil {
+		return value, codec.Decode(s, value)
+	}
+
+	var err error
+	switch {
+	case typ == durationType:
+		var duration time.Duration
+		duration, err = time.ParseDuration(s)
+		value.SetInt(int64(duration))
+	case value.CanInt():
+		var number int64
+		number, err = strconv.ParseInt(s, 10, typ.Bits())
+		value.SetInt(number)
+	case value.CanUint():
+		var number uint64
+		number, err = strconv.ParseUint(s, 10, typ.Bits())
+		value.SetUint(number)
+	case value.CanFloat():
+		var number float64
+		number, err = strconv.ParseFloat(s, typ.Bits())
+		value.SetFloat(number)
+	case typ.Kind() == reflect.Bool:
+		var b bool
+		b, err = parseBool(s)
+		value.SetBool(b)
+	case typ.Kind() == reflect.String:
+		value.SetString(s)
+	default:
+		err = fmt.Errorf("defaults of type %s are not supported", typ.String())
+	}
+	return value, err
+}
+
+// resolveFieldDefaults returns the default values of the fields of the
+// components of `T`, given by the `default` option of their `csvstruct` tag,
+// e.g., `csvstruct:",default=100"`, and the columns of the current CSV header
+// that decode them.
+func (r *Reader[T]) resolveFieldDefaults() ([]fieldDefault, error) {
+	typ := reflect.TypeFor[T]()
+
+	var defaults []fieldDefault
+	for i := 0; i < typ.NumField(); i++ {
+		componentType, ok := componentStruct(typ.Field(i).Type)
+		if !ok || !typ.Field(i).IsExported() {
+			continue
+		}
+
+		for j := 0; j < componentType.NumField(); j++ {
+			field := componentType.Field(j)
+			cell, ok := columnOptionValue(field, "default")
+			if !ok || !field.IsExported() {
+				continue
+			}
+
+			value, err := parseDefault(r.options.codecs, r.options.parseBool, field.Type, cell)
+			if err != nil {
+				return nil, fmt.Errorf("field %q of type %s has invalid default %q: %w", field.Name, componentType.String(), cell, err)
+			}
+
+			def := fieldDefault{componentIndex: i, fieldIndex: field.Index, value: value}
+			for columnNum, descriptor := range r.colDescriptors {
+				if !descriptor.ignored && len(descriptor.mapField) == 0 && slices.Equal(descriptor.componentIndex, []int{i}) && slices.Equal(descriptor.fieldIndex, field.Index) {
+					def.columns = append(def.columns, columnNum)
+				}
+			}
+			defaults = append(defaults, def)
+		}
+	}
+	return defaults, nil
+}
+
+// applyFieldDefaults sets the fields of the components that are present in
+// `t` to their default values, if their cells in `row` are empty or if they
+// have no columns in the CSV header.
+func (r *Reader[T]) applyFieldDefaults(row []string, t *T) {
+	root := reflect.ValueOf(t).Elem()
+	for _, def := range r.fieldDefaults {
+		component := componentValue(root.Field(def.componentIndex))
+		if !component.IsValid() {
+			continue
+		}
+
+		if slices.ContainsFunc(def.columns, func(columnNum int) bool {
+			return columnNum < len(row) && !r.isEmptyCell(&r.colDescriptors[columnNum], row[columnNum])
+		}) {
+			continue
+		}
+		component.FieldByIndex(def.fieldIndex).Set(def.value)
+	}
+}
+
+// WithDefaultComponents allocates the given components, e.g., 'Attributes', in
+// every decoded row, even when all their cells are empty, in which case their
+// fields have zero values. Otherwise, components whose cells are all empty are
+// left nil.
+//
+// This is useful when downstream code expects the components to exist instead
+// of checking for nil. Names that are not components of `T` are reported by
+// Read when it reads the CSV header.
+//
+// The policy can also be chosen per component with a tag on the component
+// field of `T`, which takes precedence over this option: `csv:",zero"`
+// allocates the component like this option, and `csv:",nil"` leaves it nil,
+// e.g., 'Attributes *Attributes `csv:",zero"`'.
+func WithDefaultComponents(names ...string) Option {
+	return func(o *options) {
+		o.defaultComponents = append(o.defaultComponents, names...)
+	}
+}
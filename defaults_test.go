@@ -0,0 +1,169 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestWithDefaultComponents(t *testing.T) {
+	want := []Prefab{
+		{&Info{"Alex", "Fighter"}, &Attributes{100, 10}, nil},
+		{&Info{"Jayden", "Wizard"}, &Attributes{90, 20}, nil},
+		{&Info{"Mary", "Queen"}, &Attributes{}, nil},
+		{&Info{"Player", ""}, &Attributes{}, &Player{}},
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)), csvstruct.WithDefaultComponents("Attributes"))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestWithDefaultComponents_Unknown(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)), csvstruct.WithDefaultComponents("Stats"))
+
+	var got Prefab
+	const want = `type csvstruct_test.Prefab does not have a field "Stats"`
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
+
+type TaggedPrefab struct {
+	Info       *Info       `csv:",nil"`
+	Attributes *Attributes `csv:",zero"`
+	Player     *Player
+}
+
+func TestWithDefaultComponents_Tags(t *testing.T) {
+	const data = `Info.Name,Attributes.HP,Player
+Alex,100,
+,,
+`
+
+	want := []TaggedPrefab{
+		{&Info{Name: "Alex"}, &Attributes{HP: 100}, nil},
+		{nil, &Attributes{}, nil},
+	}
+
+	// The tags take precedence over the option.
+	reader := csvstruct.NewReader[TaggedPrefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithDefaultComponents("Info"))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+type Balance struct {
+	HP       int           `csvstruct:"default=100"`
+	Speed    float64       `csvstruct:"speed,default=1.5"`
+	Cooldown time.Duration `csvstruct:",default=2s"`
+	Rarity   string        `csvstruct:",default=common"`
+}
+
+type Tuned struct {
+	Info    *Info
+	Balance *Balance
+}
+
+func TestReaderFieldDefaults(t *testing.T) {
+	const data = `Info.Name,Balance.HP,Balance.speed
+Alex,50,
+Mary,,
+Sam,0,3
+`
+	reader := csvstruct.NewReader[Tuned](csv.NewReader(strings.NewReader(data)), csvstruct.WithDefaultComponents("Balance"))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Tuned{
+		{&Info{Name: "Alex"}, &Balance{50, 1.5, 2 * time.Second, "common"}},
+		{&Info{Name: "Mary"}, &Balance{100, 1.5, 2 * time.Second, "common"}},
+		{&Info{Name: "Sam"}, &Balance{0, 3, 2 * time.Second, "common"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestReaderFieldDefaults_NilComponent(t *testing.T) {
+	const data = `Info.Name,Balance.HP
+Mary,
+`
+	reader := csvstruct.NewReader[Tuned](csv.NewReader(strings.NewReader(data)))
+
+	var got Tuned
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(Tuned{Info: &Info{Name: "Mary"}}, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+}
+
+type Stance struct {
+	Hostile bool `csvstruct:"default=yes"`
+	Flees   bool `csvstruct:",default=Y"`
+}
+
+func TestReaderFieldDefaults_Bool(t *testing.T) {
+	type Creature struct {
+		Info   *Info
+		Stance *Stance
+	}
+
+	const data = `Info.Name,Stance.Hostile,Stance.Flees
+Wolf,,
+Deer,N,
+`
+	reader := csvstruct.NewReader[Creature](csv.NewReader(strings.NewReader(data)), csvstruct.WithDefaultComponents("Stance"), csvstruct.WithBoolValues([]string{"Y", "yes"}, []string{"N", "no"}))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Creature{
+		{&Info{Name: "Wolf"}, &Stance{Hostile: true, Flees: true}},
+		{&Info{Name: "Deer"}, &Stance{Hostile: false, Flees: true}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+type BadBalance struct {
+	HP int `csvstruct:"default=lots"`
+}
+
+func TestReaderFieldDefaults_Invalid(t *testing.T) {
+	type Bad struct {
+		Balance *BadBalance
+	}
+	reader := csvstruct.NewReader[Bad](csv.NewReader(strings.NewReader("Balance.HP\n10\n")))
+
+	var got Bad
+	want := `field "HP" of type csvstruct_test.BadBalance has invalid default "lots": strconv.ParseInt: parsing "lots": invalid syntax`
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
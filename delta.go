@@ -0,0 +1,136 @@
+package csvstruct
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// HashedRows are the rows of a table together with their row hashes, i.e.,
+// Hashes[i] is the hash of the data row of Rows[i], as computed by WithRowHash.
+type HashedRows[T any] struct {
+	Rows   []T
+	Hashes []uint64
+}
+
+// hashedRow is a row of HashedRows as stored in snapshots.
+type hashedRow[T any] struct {
+	Hash uint64
+	Row  T
+}
+
+// WriteHashedSnapshot writes `rows` and their row hashes to `w` in the
+// snapshot format of WriteSnapshot, e.g., to be loaded incrementally by
+// LoadDelta in the next build.
+func WriteHashedSnapshot[T any](w io.Writer, rows HashedRows[T]) error {
+	if len(rows.Rows) != len(rows.Hashes) {
+		return fmt.Errorf("failed to write snapshot: %d rows but %d hashes", len(rows.Rows), len(rows.Hashes))
+	}
+
+	snapshot := make([]hashedRow[T], len(rows.Rows))
+	for i := range rows.Rows {
+		snapshot[i] = hashedRow[T]{rows.Hashes[i], rows.Rows[i]}
+	}
+	return WriteSnapshot(w, snapshot)
+}
+
+// ReadHashedSnapshot reads the rows and row hashes of a snapshot written by
+// WriteHashedSnapshot.
+//
+// Like ReadSnapshot, returns ErrSnapshotMismatch if the snapshot was written
+// for a different type.
+func ReadHashedSnapshot[T any](r io.Reader) (HashedRows[T], error) {
+	snapshot, err := ReadSnapshot[hashedRow[T]](r)
+	if err != nil {
+		return HashedRows[T]{}, err
+	}
+
+	rows := HashedRows[T]{make([]T, len(snapshot)), make([]uint64, len(snapshot))}
+	for i, row := range snapshot {
+		rows.Rows[i] = row.Row
+		rows.Hashes[i] = row.Hash
+	}
+	return rows, nil
+}
+
+// LoadDelta reads and validates all the rows of the first table of the CSV data
+// in `reader`, like LoadAll, but only decodes the data rows that changed since
+// `previous` was loaded, e.g., to make incremental content builds fast.
+//
+// The hash of each data row is computed from its cells, as with WithRowHash,
+// before the row is decoded. If `previous` contains a row with the same hash,
+// that row is reused without decoding or validating it again. Otherwise, the
+// row is decoded and validated. Returns the rows in the order of the CSV data
+// together with their hashes, e.g., to be written with WriteHashedSnapshot,
+// and the indices of the rows that were decoded, i.e., the rows that are new
+// or that changed.
+//
+// Reused rows are shallow copies of the rows of `previous`, i.e., they share
+// their components, and therefore their AfterDecode hooks are not called
+// again, so that they don't modify `previous`. The options must be the same
+// as those with which `previous` was loaded, since the row hashes don't
+// depend on them.
+func LoadDelta[T any](reader io.Reader, previous HashedRows[T], opts ...Option) (HashedRows[T], []int, error) {
+	if len(previous.Rows) != len(previous.Hashes) {
+		return HashedRows[T]{}, nil, fmt.Errorf("previous has %d rows but %d hashes", len(previous.Rows), len(previous.Hashes))
+	}
+
+	reused := make(map[uint64]int, len(previous.Hashes))
+	for i, hash := range previous.Hashes {
+		reused[hash] = i
+	}
+
+	var r *Reader[T]
+	var hash uint64
+	var decoded bool
+	delta := func(next RowFunc) RowFunc {
+		return func(row *Row) error {
+			if len(row.Cells) > len(r.colDescriptors) {
+				// Let the core decoding step report the error.
+				return next(row)
+			}
+
+			hash = r.hashRow(row.Cells)
+			if i, ok := reused[hash]; ok {
+				*row.Value.(*T) = previous.Rows[i]
+				r.reusedRow = true
+				decoded = false
+				return nil
+			}
+			decoded = true
+			return next(row)
+		}
+	}
+	r = NewReader[T](csv.NewReader(reader), append(slices.Clip(opts), WithMiddleware(delta))...)
+
+	var rows HashedRows[T]
+	var changed []int
+	var errs []error
+	for {
+		var t T
+		err := r.Read(&t)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return HashedRows[T]{}, nil, err
+		}
+
+		if decoded {
+			if err := validateRow(&t); err != nil {
+				errs = append(errs, fmt.Errorf("line %d: %w", r.fieldLine(0), err))
+				continue
+			}
+			changed = append(changed, len(rows.Rows))
+		}
+		rows.Rows = append(rows.Rows, t)
+		rows.Hashes = append(rows.Hashes, hash)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return HashedRows[T]{}, nil, err
+	}
+	return rows, changed, nil
+}
@@ -0,0 +1,122 @@
+package csvstruct_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestLoadDelta(t *testing.T) {
+	first, changed, err := csvstruct.LoadDelta[Unit](strings.NewReader(`Info.Name,Stats.HP
+Alex,100
+Jayden,90
+Mary,
+`), csvstruct.HashedRows[Unit]{})
+	if err != nil {
+		t.Fatalf("LoadDelta() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff([]int{0, 1, 2}, changed); diff != "" {
+		t.Errorf("LoadDelta() changed diff = %v", diff)
+	}
+
+	var buffer bytes.Buffer
+	if err := csvstruct.WriteHashedSnapshot(&buffer, first); err != nil {
+		t.Fatalf("WriteHashedSnapshot() err = %v; want %v", err, nil)
+	}
+	previous, err := csvstruct.ReadHashedSnapshot[Unit](&buffer)
+	if err != nil {
+		t.Fatalf("ReadHashedSnapshot() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(first, previous); diff != "" {
+		t.Fatalf("ReadHashedSnapshot() diff = %v", diff)
+	}
+
+	// Rows that didn't change are reused from the snapshot instead of being
+	// decoded again, even if the columns are reordered.
+	previous.Rows[2].Info.Class = "Queen"
+
+	got, changed, err := csvstruct.LoadDelta[Unit](strings.NewReader(`Stats.HP,Info.Name
+100,Alex
+95,Jayden
+,Mary
+80,Sam
+`), previous)
+	if err != nil {
+		t.Fatalf("LoadDelta() err = %v; want %v", err, nil)
+	}
+
+	want := []Unit{
+		{&Info{"Alex", ""}, &Stats{100}},
+		{&Info{"Jayden", ""}, &Stats{95}},
+		{&Info{"Mary", "Queen"}, nil},
+		{&Info{"Sam", ""}, &Stats{80}},
+	}
+	if diff := cmp.Diff(want, got.Rows); diff != "" {
+		t.Errorf("LoadDelta() rows diff = %v", diff)
+	}
+	if diff := cmp.Diff([]int{1, 3}, changed); diff != "" {
+		t.Errorf("LoadDelta() changed diff = %v", diff)
+	}
+	if got.Hashes[0] != previous.Hashes[0] || got.Hashes[2] != previous.Hashes[2] {
+		t.Errorf("LoadDelta() hashes = %v; want reused hashes %v", got.Hashes, previous.Hashes)
+	}
+}
+
+func TestLoadDelta_Invalid(t *testing.T) {
+	previous, _, err := csvstruct.LoadDelta[Unit](strings.NewReader("Info.Name,Stats.HP\nAlex,100\n"), csvstruct.HashedRows[Unit]{})
+	if err != nil {
+		t.Fatalf("LoadDelta() err = %v; want %v", err, nil)
+	}
+
+	const data = `Info.Name,Stats.HP
+Alex,100
+Mary,-1
+`
+	want := "line 3: Stats: HP must be positive"
+	if _, _, err := csvstruct.LoadDelta[Unit](strings.NewReader(data), previous); err == nil || err.Error() != want {
+		t.Errorf("LoadDelta() err = %v; want %v", err, want)
+	}
+}
+
+// Tally counts the calls to its AfterDecode hook.
+type Tally struct {
+	Name  string
+	Calls int
+}
+
+func (t *Tally) AfterDecode() error {
+	t.Calls++
+	return nil
+}
+
+type Ledger struct {
+	Tally *Tally
+}
+
+func TestLoadDelta_AfterDecode(t *testing.T) {
+	const data = `Tally.Name
+Alex
+`
+	previous, _, err := csvstruct.LoadDelta[Ledger](strings.NewReader(data), csvstruct.HashedRows[Ledger]{})
+	if err != nil {
+		t.Fatalf("LoadDelta() err = %v; want %v", err, nil)
+	}
+
+	got, _, err := csvstruct.LoadDelta[Ledger](strings.NewReader(data+"Mary\n"), previous)
+	if err != nil {
+		t.Fatalf("LoadDelta() err = %v; want %v", err, nil)
+	}
+
+	// The hook of the reused row doesn't run again, so the previous rows are
+	// not modified.
+	want := []Ledger{{&Tally{"Alex", 1}}, {&Tally{"Mary", 1}}}
+	if diff := cmp.Diff(want, got.Rows); diff != "" {
+		t.Errorf("LoadDelta() rows diff = %v", diff)
+	}
+	if diff := cmp.Diff([]Ledger{{&Tally{"Alex", 1}}}, previous.Rows); diff != "" {
+		t.Errorf("LoadDelta() previous rows diff = %v", diff)
+	}
+}
@@ -0,0 +1,124 @@
+package csvstruct
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var diceType = reflect.TypeFor[Dice]()
+
+// Dice is a dice expression in tabletop notation, e.g., '2d6+3', which means
+// roll 2 dice of 6 sides each and add 3 to the total.
+//
+// Component fields of type Dice are parsed from cells written in dice notation.
+type Dice struct {
+	// Number of dice to roll.
+	Count int
+	// Number of sides of each die.
+	Sides int
+	// Constant added to the total. It's negative for expressions like '1d4-1'.
+	Modifier int
+}
+
+// ParseDice parses a dice expression, e.g., '2d6+3', '1d4-1', 'd20' (which is
+// the same as '1d20'), or a constant, e.g., '5'.
+func ParseDice(s string) (Dice, error) {
+	expr := strings.TrimSpace(s)
+
+	index := strings.IndexAny(expr, "dD")
+	if index < 0 {
+		modifier, err := strconv.Atoi(expr)
+		if err != nil {
+			return Dice{}, fmt.Errorf("invalid dice expression %q", s)
+		}
+		return Dice{Modifier: modifier}, nil
+	}
+
+	count := 1
+	if index > 0 {
+		var err error
+		count, err = strconv.Atoi(expr[:index])
+		if err != nil || count < 0 {
+			return Dice{}, fmt.Errorf("invalid dice count in expression %q", s)
+		}
+	}
+
+	sidesAndModifier := expr[index+1:]
+
+	modifier := 0
+	if index := strings.IndexAny(sidesAndModifier, "+-"); index >= 0 {
+		var err error
+		modifier, err = strconv.Atoi(sidesAndModifier[index:])
+		if err != nil {
+			return Dice{}, fmt.Errorf("invalid dice modifier in expression %q", s)
+		}
+		sidesAndModifier = sidesAndModifier[:index]
+	}
+
+	sides, err := strconv.Atoi(sidesAndModifier)
+	if err != nil || sides <= 0 {
+		return Dice{}, fmt.Errorf("invalid dice sides in expression %q", s)
+	}
+
+	return Dice{count, sides, modifier}, nil
+}
+
+// Roll rolls the dice and returns the total, including the modifier.
+//
+// If `rng` is nil, the top-level random number generator is used.
+func (d Dice) Roll(rng *rand.Rand) int {
+	total := d.Modifier
+	for i := 0; i < d.Count; i++ {
+		if rng == nil {
+			total += rand.IntN(d.Sides) + 1
+		} else {
+			total += rng.IntN(d.Sides) + 1
+		}
+	}
+	return total
+}
+
+// Min returns the smallest total that Roll can return.
+func (d Dice) Min() int {
+	return d.Count + d.Modifier
+}
+
+// Max returns the largest total that Roll can return.
+func (d Dice) Max() int {
+	return d.Count*d.Sides + d.Modifier
+}
+
+// diceCodec is the Codec for Dice.
+type diceCodec struct{}
+
+func (diceCodec) Decode(cell string, dst reflect.Value) error {
+	dice, err := ParseDice(cell)
+	if err != nil {
+		return err
+	}
+	dst.Set(reflect.ValueOf(dice))
+	return nil
+}
+
+func (diceCodec) Encode(src reflect.Value) (string, error) {
+	return src.Interface().(Dice).String(), nil
+}
+
+// String returns the dice expression in tabletop notation, e.g., '2d6+3'.
+func (d Dice) String() string {
+	if d.Count == 0 {
+		return strconv.Itoa(d.Modifier)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%dd%d", d.Count, d.Sides)
+	if d.Modifier > 0 {
+		fmt.Fprintf(&b, "+%d", d.Modifier)
+	} else if d.Modifier < 0 {
+		fmt.Fprintf(&b, "%d", d.Modifier)
+	}
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestParseDice(t *testing.T) {
+	tests := []struct {
+		expr string
+		want csvstruct.Dice
+	}{
+		{"2d6+3", csvstruct.Dice{2, 6, 3}},
+		{"1d4-1", csvstruct.Dice{1, 4, -1}},
+		{"d20", csvstruct.Dice{1, 20, 0}},
+		{"3D8", csvstruct.Dice{3, 8, 0}},
+		{"5", csvstruct.Dice{0, 0, 5}},
+	}
+
+	for _, test := range tests {
+		got, err := csvstruct.ParseDice(test.expr)
+		if err != nil {
+			t.Fatalf("ParseDice(%q) err = %v; want %v", test.expr, err, nil)
+		}
+
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("ParseDice(%q) diff = %v", test.expr, diff)
+		}
+	}
+}
+
+func TestParseDice_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "2d", "2d0", "xd6", "2d6+", "2d6*2"} {
+		if _, err := csvstruct.ParseDice(expr); err == nil {
+			t.Errorf("ParseDice(%q) err = %v; want error", expr, err)
+		}
+	}
+}
+
+func TestDiceRoll(t *testing.T) {
+	dice := csvstruct.Dice{2, 6, 3}
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for i := 0; i < 100; i++ {
+		if got := dice.Roll(rng); got < dice.Min() || got > dice.Max() {
+			t.Fatalf("Roll() = %d; want value in [%d, %d]", got, dice.Min(), dice.Max())
+		}
+	}
+}
+
+type Weapon struct {
+	Name   string
+	Damage csvstruct.Dice
+}
+
+type Item struct {
+	Weapon *Weapon
+}
+
+func TestReaderDice(t *testing.T) {
+	const data = `Weapon.Name,Weapon.Damage
+Sword,1d8+1
+Dagger,d4
+`
+
+	want := []Item{
+		{&Weapon{"Sword", csvstruct.Dice{1, 8, 1}}},
+		{&Weapon{"Dagger", csvstruct.Dice{1, 4, 0}}},
+	}
+
+	reader := csvstruct.NewReader[Item](csv.NewReader(strings.NewReader(data)))
+
+	for _, want := range want {
+		var got Item
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+}
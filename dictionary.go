@@ -0,0 +1,199 @@
+package csvstruct
+
+import (
+	"errors"
+	"reflect"
+)
+
+// errInvalidDictionary is returned when reading a snapshot whose dictionaries
+// don't match the rows.
+var errInvalidDictionary = errors.New("invalid dictionary")
+
+// snapshotDictionary is a dictionary-encoded string field of the rows of a
+// snapshot.
+type snapshotDictionary struct {
+	// Indices of the field, starting at the type of the rows, through structs
+	// and pointers to structs.
+	Path []int
+	// Distinct values of the field. The first value is always the empty string.
+	Values []string
+	// Codes of the rows, i.e., Values[Codes[i]] is the value of the field of
+	// row i.
+	Codes []uint32
+}
+
+// stringPaths returns the paths of the string fields of the struct type `typ`,
+// including those of nested structs and pointers to structs. Fields of
+// recursive types are only visited once along each path.
+func stringPaths(typ reflect.Type, prefix []int, visiting map[reflect.Type]bool) [][]int {
+	if visiting[typ] {
+		return nil
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	var paths [][]int
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path := append(prefix[:len(prefix):len(prefix)], i)
+		if field.Type.Kind() == reflect.String {
+			paths = append(paths, path)
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			paths = append(paths, stringPaths(fieldType, path, visiting)...)
+		}
+	}
+	return paths
+}
+
+// lookupPath returns the field of `v` at `path`, or an invalid value if a
+// pointer along the path is nil.
+func lookupPath(v reflect.Value, path []int) reflect.Value {
+	for _, i := range path {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// detachPath copies the structs pointed to along `path` in `v`, which must be
+// addressable, so that the field at `path` can be modified without modifying
+// the structs shared with other values. Pointers in `detached` were already
+// copied. Returns the field, or an invalid value if a pointer along the path is
+// nil.
+func detachPath(v reflect.Value, path []int, detached map[uintptr]bool) reflect.Value {
+	for _, i := range path {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			if !detached[v.Pointer()] {
+				elem := reflect.New(v.Type().Elem())
+				elem.Elem().Set(v.Elem())
+				v.Set(elem)
+				detached[v.Pointer()] = true
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// encodeDictionaries dictionary-encodes the string fields of `rows` that have
+// few distinct values, e.g., class names or rarities, which are repeated in
+// many rows. Returns the dictionaries and a copy of `rows` in which those
+// fields are empty, so that they take no space when the rows are encoded.
+// `rows` is not modified.
+func encodeDictionaries[T any](rows []T) ([]snapshotDictionary, []T) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct || len(rows) == 0 {
+		return nil, rows
+	}
+
+	var dictionaries []snapshotDictionary
+	for _, path := range stringPaths(typ, nil, map[reflect.Type]bool{}) {
+		dictionary := snapshotDictionary{Path: path, Values: []string{""}, Codes: make([]uint32, len(rows))}
+		codes := map[string]uint32{"": 0}
+		for i := range rows {
+			field := lookupPath(reflect.ValueOf(&rows[i]).Elem(), path)
+			if !field.IsValid() {
+				continue
+			}
+
+			value := field.String()
+			code, ok := codes[value]
+			if !ok {
+				code = uint32(len(dictionary.Values))
+				codes[value] = code
+				dictionary.Values = append(dictionary.Values, value)
+			}
+			dictionary.Codes[i] = code
+		}
+
+		// A dictionary only saves space if the values are repeated.
+		if len(dictionary.Values)*2 <= len(rows) {
+			dictionaries = append(dictionaries, dictionary)
+		}
+	}
+	if len(dictionaries) == 0 {
+		return nil, rows
+	}
+
+	encoded := make([]T, len(rows))
+	copy(encoded, rows)
+	detached := map[uintptr]bool{}
+	for i := range encoded {
+		row := reflect.ValueOf(&encoded[i]).Elem()
+		for _, dictionary := range dictionaries {
+			if dictionary.Codes[i] == 0 {
+				continue
+			}
+			detachPath(row, dictionary.Path, detached).SetString("")
+		}
+	}
+	return dictionaries, encoded
+}
+
+// decodeDictionaries restores the string fields of `rows` from
+// `dictionaries`, as encoded by encodeDictionaries.
+func decodeDictionaries[T any](dictionaries []snapshotDictionary, rows []T) error {
+	for _, dictionary := range dictionaries {
+		if len(dictionary.Codes) != len(rows) {
+			return errInvalidDictionary
+		}
+
+		for i, code := range dictionary.Codes {
+			if code == 0 {
+				continue
+			}
+			if int(code) >= len(dictionary.Values) {
+				return errInvalidDictionary
+			}
+
+			field, err := snapshotField(reflect.ValueOf(&rows[i]).Elem(), dictionary.Path)
+			if err != nil {
+				return err
+			}
+			field.SetString(dictionary.Values[code])
+		}
+	}
+	return nil
+}
+
+// snapshotField returns the string field of `v` at `path`, or
+// errInvalidDictionary if `path` doesn't lead to a string field of a non-nil
+// struct.
+func snapshotField(v reflect.Value, path []int) (reflect.Value, error) {
+	for _, i := range path {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, errInvalidDictionary
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct || i < 0 || i >= v.NumField() {
+			return reflect.Value{}, errInvalidDictionary
+		}
+		v = v.Field(i)
+	}
+	if v.Kind() != reflect.String || !v.CanSet() {
+		return reflect.Value{}, errInvalidDictionary
+	}
+	return v, nil
+}
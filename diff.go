@@ -0,0 +1,91 @@
+package csvstruct
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RowChange is a change to a row, identified by its key, between two versions
+// of a table.
+type RowChange[K comparable, T any] struct {
+	// Key of the row.
+	Key K
+	// Row in the old table. It's the zero value if the row was added.
+	Old T
+	// Row in the new table. It's the zero value if the row was removed.
+	New T
+}
+
+// TableDiff contains the changes between two versions of a table.
+type TableDiff[K comparable, T any] struct {
+	// Rows that are only in the new table, in the order of the new table.
+	Added []RowChange[K, T]
+	// Rows that are only in the old table, in the order of the old table.
+	Removed []RowChange[K, T]
+	// Rows that are in both tables but are different, in the order of the new
+	// table.
+	Modified []RowChange[K, T]
+}
+
+// Empty returns whether there are no changes.
+func (d *TableDiff[K, T]) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// DiffTables compares two versions of a table, e.g., before and after the CSV
+// file is reloaded, and returns the rows that were added, removed, and
+// modified. Rows are matched by the key computed by `keyFn` and compared with
+// reflect.DeepEqual.
+//
+// This allows running systems to apply incremental updates when data is
+// reloaded instead of replacing all the rows.
+//
+// Returns an error if two rows of the same table have the same key.
+func DiffTables[T any, K comparable](oldRows, newRows []T, keyFn func(*T) K) (TableDiff[K, T], error) {
+	oldIndex, err := indexRows(oldRows, keyFn)
+	if err != nil {
+		return TableDiff[K, T]{}, fmt.Errorf("old table: %w", err)
+	}
+
+	newIndex, err := indexRows(newRows, keyFn)
+	if err != nil {
+		return TableDiff[K, T]{}, fmt.Errorf("new table: %w", err)
+	}
+
+	var diff TableDiff[K, T]
+	for i := range newRows {
+		key := keyFn(&newRows[i])
+
+		j, ok := oldIndex[key]
+		if !ok {
+			diff.Added = append(diff.Added, RowChange[K, T]{Key: key, New: newRows[i]})
+			continue
+		}
+
+		if !reflect.DeepEqual(oldRows[j], newRows[i]) {
+			diff.Modified = append(diff.Modified, RowChange[K, T]{key, oldRows[j], newRows[i]})
+		}
+	}
+
+	for i := range oldRows {
+		key := keyFn(&oldRows[i])
+		if _, ok := newIndex[key]; !ok {
+			diff.Removed = append(diff.Removed, RowChange[K, T]{Key: key, Old: oldRows[i]})
+		}
+	}
+
+	return diff, nil
+}
+
+// indexRows returns the index of each row by key.
+func indexRows[T any, K comparable](rows []T, keyFn func(*T) K) (map[K]int, error) {
+	index := make(map[K]int, len(rows))
+	for i := range rows {
+		key := keyFn(&rows[i])
+		if _, ok := index[key]; ok {
+			return nil, fmt.Errorf("row with key %v: %w", key, ErrDuplicateKey)
+		}
+		index[key] = i
+	}
+	return index, nil
+}
@@ -0,0 +1,49 @@
+package csvstruct_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func prefabName(p *Prefab) string { return p.Info.Name }
+
+func TestDiffTables(t *testing.T) {
+	oldRows := []Prefab{
+		{&Info{"Alex", "Fighter"}, &Attributes{100, 10}, nil},
+		{&Info{"Jayden", "Wizard"}, &Attributes{90, 20}, nil},
+		{&Info{"Mary", "Queen"}, nil, nil},
+	}
+
+	newRows := []Prefab{
+		{&Info{"Alex", "Fighter"}, &Attributes{100, 10}, nil},
+		{&Info{"Mary", "Queen"}, &Attributes{50, 5}, nil},
+		{&Info{"Player", ""}, nil, &Player{}},
+	}
+
+	want := csvstruct.TableDiff[string, Prefab]{
+		Added: []csvstruct.RowChange[string, Prefab]{
+			{Key: "Player", New: newRows[2]},
+		},
+		Removed: []csvstruct.RowChange[string, Prefab]{
+			{Key: "Jayden", Old: oldRows[1]},
+		},
+		Modified: []csvstruct.RowChange[string, Prefab]{
+			{Key: "Mary", Old: oldRows[2], New: newRows[1]},
+		},
+	}
+
+	got, err := csvstruct.DiffTables(oldRows, newRows, prefabName)
+	if err != nil {
+		t.Fatalf("DiffTables() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("DiffTables() diff = %v", diff)
+	}
+
+	if got, err := csvstruct.DiffTables(newRows, newRows, prefabName); err != nil || !got.Empty() {
+		t.Fatalf("DiffTables() = %v, %v; want empty diff", got, err)
+	}
+}
@@ -0,0 +1,12 @@
+// Package csvstruct imports multiply-typed structured data from CSV to Go types,
+// and exports it back to CSV.
+//
+// The CSV header contains qualified names, e.g., 'Info.Name', of the fields of
+// the components of a type `T`, i.e., of the fields of `T` that are structs or
+// pointers to structs. Pointer components are nil unless one of their cells in
+// a data row is non-empty, including the cell of the column with the bare name
+// of the component, e.g., 'Info', whereas value components are always present.
+// Reader decodes each data row into a value of `T`, and
+// Writer encodes values of `T` into data rows after a header derived from `T`,
+// so that data edited in code can be round-tripped back to CSV files.
+package csvstruct
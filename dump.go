@@ -0,0 +1,69 @@
+package csvstruct
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// dumpValue formats a field value for Dump. Strings are quoted so that empty
+// strings and whitespace are visible.
+func dumpValue(value reflect.Value) string {
+	if value.Kind() == reflect.String {
+		return fmt.Sprintf("%q", value.String())
+	}
+	return fmt.Sprintf("%+v", value.Interface())
+}
+
+// Dump writes `rows` to `w` in a human-readable format for debugging, with one
+// block per row that lists the fields of each component. Nil components are
+// omitted, and marker components are listed by name only, e.g.:
+//
+//	#1
+//	  Info
+//	    Name: "Alex"
+//	    Class: "Fighter"
+//	  Player
+//
+// Fields of `T` that are not components are listed before the components.
+func Dump[T any](w io.Writer, rows []T) error {
+	writer := bufio.NewWriter(w)
+	for i, row := range rows {
+		fmt.Fprintf(writer, "#%d\n", i+1)
+
+		value := reflect.ValueOf(row)
+		if value.Kind() != reflect.Struct {
+			fmt.Fprintf(writer, "  %s\n", dumpValue(value))
+			continue
+		}
+
+		typ := value.Type()
+		for j := 0; j < typ.NumField(); j++ {
+			field := typ.Field(j)
+			if _, ok := componentStruct(field.Type); field.IsExported() && !ok {
+				fmt.Fprintf(writer, "  %s: %s\n", field.Name, dumpValue(value.Field(j)))
+			}
+		}
+
+		for j := 0; j < typ.NumField(); j++ {
+			field := typ.Field(j)
+			if _, ok := componentStruct(field.Type); !field.IsExported() || !ok {
+				continue
+			}
+
+			component := componentValue(value.Field(j))
+			if !component.IsValid() {
+				continue
+			}
+
+			fmt.Fprintf(writer, "  %s\n", field.Name)
+			for k := 0; k < component.NumField(); k++ {
+				if subfield := component.Type().Field(k); subfield.IsExported() {
+					fmt.Fprintf(writer, "    %s: %s\n", subfield.Name, dumpValue(component.Field(k)))
+				}
+			}
+		}
+	}
+	return writer.Flush()
+}
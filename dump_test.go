@@ -0,0 +1,46 @@
+package csvstruct_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Record struct {
+	ID     int
+	Info   Info
+	Weapon *Weapon
+}
+
+func TestDump(t *testing.T) {
+	rows := []Record{
+		{7, Info{"Alex", ""}, &Weapon{"Sword", csvstruct.Dice{1, 8, 1}}},
+		{8, Info{"Mary", "Queen"}, nil},
+	}
+
+	var got strings.Builder
+	if err := csvstruct.Dump(&got, rows); err != nil {
+		t.Fatalf("Dump() err = %v; want %v", err, nil)
+	}
+
+	want := `#1
+  ID: 7
+  Info
+    Name: "Alex"
+    Class: ""
+  Weapon
+    Name: "Sword"
+    Damage: 1d8+1
+#2
+  ID: 8
+  Info
+    Name: "Mary"
+    Class: "Queen"
+`
+
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("Dump() diff = %v", diff)
+	}
+}
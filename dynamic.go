@@ -0,0 +1,274 @@
+package csvstruct
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// dynamicColumn describes a column of the CSV header read by a DynamicReader.
+type dynamicColumn struct {
+	// Qualified name of the column, e.g., 'MyComponent.MyField'.
+	name string
+	// Subschema of the column's property.
+	schema *jsonSchema
+	// Compiled pattern of the subschema, or nil if it has none.
+	pattern *regexp.Regexp
+	// Whether the column's property is required.
+	required bool
+}
+
+// DynamicReader parses CSV data into maps, as described by a JSON Schema
+// document rather than a Go type, e.g., so that tools without the Go types of
+// the tables can still decode and validate them.
+//
+// This is thread compatible, i.e., it's safe for non-concurrent use and it can
+// be combined with external synchronization so it can be called concurrently.
+type DynamicReader struct {
+	// Underlying CSV reader.
+	reader *csv.Reader
+	// Permanent error. If there is one, it's returned on all Read calls.
+	permanentErr error
+	// JSON Schema document given to NewDynamicReader.
+	schema *jsonSchema
+	// Columns of the CSV header, or nil if it hasn't been read yet.
+	columns []dynamicColumn
+}
+
+// enumMatches returns whether the value `enum` of an enum matches `cell`,
+// whose parsed value is `value`, or `number` for integers and numbers. JSON
+// numbers are compared by value, e.g., the enum value 1 matches the cells '1'
+// and '1.0' of a number, and strings are compared with the cell, e.g., so
+// that enums of strings also match cells of other types.
+func enumMatches(enum any, cell string, value any, number float64) bool {
+	switch enum := enum.(type) {
+	case string:
+		return enum == cell
+	case float64:
+		switch value.(type) {
+		case int64, float64:
+			return enum == number
+		}
+	case bool:
+		return enum == value
+	}
+	return false
+}
+
+// decodeCell parses the non-empty `cell` of `column` as the type of its
+// subschema, and checks it against the subschema's enum, range, length, and
+// pattern.
+func decodeCell(column *dynamicColumn, cell string) (any, error) {
+	schema := column.schema
+	var value any
+	var number float64
+	var err error
+	switch schema.Type {
+	case "integer":
+		var n int64
+		n, err = strconv.ParseInt(cell, 10, 64)
+		value, number = n, float64(n)
+	case "number":
+		number, err = strconv.ParseFloat(cell, 64)
+		value = number
+	case "boolean":
+		value, err = strconv.ParseBool(cell)
+	default:
+		if schema.Format == "date-time" {
+			_, err = time.Parse(time.RFC3339, cell)
+		}
+		value = cell
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if schema.Enum != nil && !slices.ContainsFunc(schema.Enum, func(enum any) bool { return enumMatches(enum, cell, value, number) }) {
+		enum := make([]string, len(schema.Enum))
+		for i, value := range schema.Enum {
+			enum[i] = fmt.Sprint(value)
+		}
+		return nil, fmt.Errorf("invalid value %q; want one of %q", cell, enum)
+	}
+	if minimum, ok := schema.Minimum.(float64); ok && number < minimum {
+		return nil, fmt.Errorf("value %s is less than the minimum %v", cell, minimum)
+	}
+	if maximum, ok := schema.Maximum.(float64); ok && number > maximum {
+		return nil, fmt.Errorf("value %s is greater than the maximum %v", cell, maximum)
+	}
+	if len([]rune(cell)) < schema.MinLength {
+		return nil, fmt.Errorf("value %q is shorter than %d characters", cell, schema.MinLength)
+	}
+	if column.pattern != nil && !column.pattern.MatchString(cell) {
+		return nil, fmt.Errorf("value %q does not match the pattern %q", cell, schema.Pattern)
+	}
+	return value, nil
+}
+
+// readHeader reads the CSV header row and checks it against the schema, i.e.,
+// that all its columns are properties of the schema and that all the required
+// properties are columns.
+func (r *DynamicReader) readHeader() error {
+	row, err := r.reader.Read()
+	if err == io.EOF {
+		return fmt.Errorf("failed to read CSV header: %v", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	var properties Schema
+	for name := range r.schema.Properties {
+		properties.Columns = append(properties.Columns, name)
+	}
+	slices.Sort(properties.Columns)
+
+	var headerErr HeaderError
+	columns := make([]dynamicColumn, len(row))
+	for i, name := range row {
+		schema, ok := r.schema.Properties[name]
+		if !ok {
+			headerErr.Columns = append(headerErr.Columns, &ColumnError{Column: i + 1, Name: name, Err: errors.New("column is not a property of the schema"), Suggestions: SuggestColumns(name, properties)})
+			continue
+		}
+
+		columns[i] = dynamicColumn{name: name, schema: schema, required: slices.Contains(r.schema.Required, name)}
+		if len(schema.Pattern) > 0 {
+			if columns[i].pattern, err = regexp.Compile(schema.Pattern); err != nil {
+				headerErr.Columns = append(headerErr.Columns, &ColumnError{Column: i + 1, Name: name, Err: fmt.Errorf("invalid pattern in the schema: %v", err)})
+			}
+		}
+	}
+	for _, name := range r.schema.Required {
+		if !slices.Contains(row, name) {
+			headerErr.Columns = append(headerErr.Columns, &ColumnError{Column: len(row) + 1, Name: name, Err: errors.New("required column is missing")})
+		}
+	}
+	if len(headerErr.Columns) > 0 {
+		return &headerErr
+	}
+
+	r.columns = columns
+	return nil
+}
+
+// Read reads the next CSV row and returns its cells decoded as the types of
+// their properties, indexed by column name, e.g., 'MyComponent.MyField'.
+// Integers are int64, numbers are float64, booleans are bool, and other cells
+// are strings. Empty cells are omitted, unless their property has a default,
+// in which case the default is returned instead.
+//
+// It's expected that the first row is the CSV header, whose columns must be
+// properties of the schema, and which must include the required properties.
+//
+// Returns io.EOF at the end of the CSV data, or a *ParseError if a cell
+// doesn't satisfy its property, e.g., an integer out of range or a value that
+// is not in its enum. Like Reader, errors are permanent, i.e., once Read
+// returns an error, including a *HeaderError, all subsequent calls return the
+// same error.
+func (r *DynamicReader) Read() (map[string]any, error) {
+	if r.permanentErr != nil {
+		return nil, r.permanentErr
+	}
+
+	values, err := r.read()
+	if err != nil {
+		r.permanentErr = err
+		return nil, err
+	}
+	return values, nil
+}
+
+// read implements Read.
+func (r *DynamicReader) read() (map[string]any, error) {
+	if r.columns == nil {
+		if err := r.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	row, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any, len(row))
+	for i, cell := range row {
+		if i >= len(r.columns) {
+			line, _ := r.reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: row has %d cells but the CSV header has %d columns", line, len(row), len(r.columns))
+		}
+
+		column := &r.columns[i]
+		var err error
+		switch {
+		case len(cell) > 0:
+			values[column.name], err = decodeCell(column, cell)
+		case column.required:
+			err = errors.New("required field is empty")
+		case column.schema.Default != nil:
+			values[column.name] = column.schema.Default
+		}
+		if err != nil {
+			line, _ := r.reader.FieldPos(i)
+			return nil, &ParseError{Line: line, Column: i + 1, Name: column.name, Field: column.name, Err: err}
+		}
+	}
+	return values, nil
+}
+
+// ReadAll reads all the remaining CSV rows. See Read. Returns nil rows if
+// there is an error.
+func (r *DynamicReader) ReadAll() ([]map[string]any, error) {
+	var rows []map[string]any
+	for {
+		values, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, values)
+	}
+}
+
+// NewDynamicReader returns a reader that parses the CSV data of `reader` as
+// described by `schema`, which is a JSON Schema document of an object whose
+// properties are the columns, e.g., as returned by JSONSchemaFor, so that the
+// same document can be shared with teams and tools that don't have the Go
+// types.
+//
+// Only the keywords written by JSONSchemaFor are supported, i.e., 'type',
+// 'format', 'pattern', 'minLength', 'minimum', 'maximum', 'enum', 'default',
+// and 'required'. Other keywords are ignored. Enum values are compared with
+// the parsed cells, e.g., the enum value 2 matches the cell '2' of an integer.
+//
+// Only JSON documents are supported. YAML documents must be converted to JSON
+// first, since this package has no YAML dependency. The `csvstruct validate`
+// command validates CSV data against a schema from the shell.
+func NewDynamicReader(reader *csv.Reader, schema []byte) (*DynamicReader, error) {
+	var document jsonSchema
+	if err := json.Unmarshal(schema, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON Schema: %v", err)
+	}
+	if document.Type != "object" || document.Properties == nil {
+		return nil, fmt.Errorf("JSON Schema of type %q with %d properties; want an object with properties", document.Type, len(document.Properties))
+	}
+	for name, property := range document.Properties {
+		if property == nil {
+			return nil, fmt.Errorf("JSON Schema property %q is not an object", name)
+		}
+		// JSON numbers are decoded as float64, but integers are read as int64.
+		if number, ok := property.Default.(float64); ok && property.Type == "integer" {
+			property.Default = int64(number)
+		}
+	}
+	return &DynamicReader{reader: reader, schema: &document}, nil
+}
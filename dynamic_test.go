@@ -0,0 +1,156 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestDynamicReader(t *testing.T) {
+	schema, err := csvstruct.JSONSchemaFor[Vault]()
+	if err != nil {
+		t.Fatalf("JSONSchemaFor() err = %v; want %v", err, nil)
+	}
+
+	const data = `Relic.Name,Relic.Rarity,Relic.level,Relic.Weight,Player
+Crown,epic,3,2.5,1
+Ring,,,,
+`
+
+	reader, err := csvstruct.NewDynamicReader(csv.NewReader(strings.NewReader(data)), schema)
+	if err != nil {
+		t.Fatalf("NewDynamicReader() err = %v; want %v", err, nil)
+	}
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []map[string]any{
+		{"Relic.Name": "Crown", "Relic.Rarity": "epic", "Relic.level": int64(3), "Relic.Weight": 2.5, "Player": true},
+		{"Relic.Name": "Ring", "Relic.Rarity": "common", "Relic.level": int64(1)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestDynamicReader_Invalid(t *testing.T) {
+	schema, err := csvstruct.JSONSchemaFor[Vault]()
+	if err != nil {
+		t.Fatalf("JSONSchemaFor() err = %v; want %v", err, nil)
+	}
+
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"Relic.Name,Relic.level\nCrown,256\n", "line 2, column 2 (Relic.level): value 256 is greater than the maximum 255"},
+		{"Relic.Name,Relic.Rarity\nCrown,legendary\n", `line 2, column 2 (Relic.Rarity): invalid value "legendary"; want one of ["common" "rare" "epic"]`},
+		{"Relic.Name,Relic.Rarity\n,rare\n", "line 2, column 1 (Relic.Name): required field is empty"},
+		{"Relic.Name,Relic.level\nCrown, \n", `line 2, column 2 (Relic.level): strconv.ParseInt: parsing " ": invalid syntax`},
+		{"Relic.Nmae\nCrown\n", "invalid CSV header:\ncolumn 1 (Relic.Nmae): column is not a property of the schema; did you mean Relic.Name?\ncolumn 2 (Relic.Name): required column is missing"},
+	}
+
+	for _, test := range tests {
+		reader, err := csvstruct.NewDynamicReader(csv.NewReader(strings.NewReader(test.data)), schema)
+		if err != nil {
+			t.Fatalf("NewDynamicReader() err = %v; want %v", err, nil)
+		}
+
+		if _, err := reader.Read(); err == nil || err.Error() != test.want {
+			t.Errorf("Read() err = %v; want %v", err, test.want)
+		}
+		// Errors are permanent.
+		if _, err := reader.Read(); err == nil || err.Error() != test.want {
+			t.Errorf("Read() err = %v; want %v", err, test.want)
+		}
+	}
+}
+
+func TestDynamicReader_ReadAllError(t *testing.T) {
+	schema, err := csvstruct.JSONSchemaFor[Vault]()
+	if err != nil {
+		t.Fatalf("JSONSchemaFor() err = %v; want %v", err, nil)
+	}
+
+	const data = `Relic.Name,Relic.level
+Crown,3
+Ring,256
+`
+
+	reader, err := csvstruct.NewDynamicReader(csv.NewReader(strings.NewReader(data)), schema)
+	if err != nil {
+		t.Fatalf("NewDynamicReader() err = %v; want %v", err, nil)
+	}
+
+	got, err := reader.ReadAll()
+	if err == nil {
+		t.Fatalf("ReadAll() err = %v; want error", err)
+	}
+	if got != nil {
+		t.Errorf("ReadAll() = %v; want %v", got, nil)
+	}
+}
+
+func TestNewDynamicReader_InvalidSchema(t *testing.T) {
+	for _, schema := range []string{`[]`, `{"type": "string"}`, `{"type": "object", "properties": {"A": 1}}`, `{"type": "object", "properties": {"A": null}}`} {
+		if _, err := csvstruct.NewDynamicReader(csv.NewReader(strings.NewReader("")), []byte(schema)); err == nil {
+			t.Errorf("NewDynamicReader(%s) err = %v; want error", schema, err)
+		}
+	}
+
+	reader, err := csvstruct.NewDynamicReader(csv.NewReader(strings.NewReader("")), []byte(`{"type": "object", "properties": {}}`))
+	if err != nil {
+		t.Fatalf("NewDynamicReader() err = %v; want %v", err, nil)
+	}
+	if _, err := reader.Read(); err == nil || errors.Is(err, io.EOF) {
+		t.Errorf("Read() err = %v; want missing CSV header error", err)
+	}
+}
+
+func TestDynamicReader_NumericEnum(t *testing.T) {
+	const schema = `{
+  "type": "object",
+  "properties": {
+    "Relic.Tier": {"type": "integer", "enum": [1, 2, 3]},
+    "Relic.Scale": {"type": "number", "enum": [0.5, 1]},
+    "Relic.Cursed": {"type": "boolean", "enum": [false]}
+  }
+}`
+
+	const data = `Relic.Tier,Relic.Scale,Relic.Cursed
+2,1.0,false
+`
+
+	reader, err := csvstruct.NewDynamicReader(csv.NewReader(strings.NewReader(data)), []byte(schema))
+	if err != nil {
+		t.Fatalf("NewDynamicReader() err = %v; want %v", err, nil)
+	}
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []map[string]any{{"Relic.Tier": int64(2), "Relic.Scale": 1.0, "Relic.Cursed": false}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+
+	reader, err = csvstruct.NewDynamicReader(csv.NewReader(strings.NewReader("Relic.Tier\n4\n")), []byte(schema))
+	if err != nil {
+		t.Fatalf("NewDynamicReader() err = %v; want %v", err, nil)
+	}
+
+	want2 := `line 2, column 1 (Relic.Tier): invalid value "4"; want one of ["1" "2" "3"]`
+	if _, err := reader.Read(); err == nil || err.Error() != want2 {
+		t.Errorf("Read() err = %v; want %v", err, want2)
+	}
+}
@@ -0,0 +1,120 @@
+package csvstruct
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// isNumber returns whether the column is a number field that is decoded
+// without a codec, union, or time layout.
+func (d *colDescriptor) isNumber() bool {
+	if d.codec != nil || d.union != nil || len(d.timeLayout) > 0 {
+		return false
+	}
+
+	switch d.kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return d.typ != durationType
+	}
+	return false
+}
+
+// isEmptyCell returns whether `cell` of the column described by `descriptor`
+// is empty, i.e., whether it's skipped when decoding. Empty cells of number
+// fields are not empty with the WithEmptyNumbersAsZero option.
+func (r *Reader[T]) isEmptyCell(descriptor *colDescriptor, cell string) bool {
+	return len(cell) == 0 && !(r.options.emptyNumbersAsZero && descriptor.isNumber())
+}
+
+// checkCompleteComponents returns an error if a component has both empty and
+// non-empty cells in `row`. Only used with the WithCompleteComponents option.
+//
+// Presence columns, i.e., the columns with the bare name of a component, are
+// not checked, since a component can be present with all of its fields empty.
+func (r *Reader[T]) checkCompleteComponents(row []string) error {
+	// Column of the first empty and non-empty cell of each component, indexed by
+	// component name.
+	empty := map[string]int{}
+	filled := map[string]int{}
+	for columnNum, cell := range row {
+		descriptor := &r.colDescriptors[columnNum]
+		if descriptor.ignored || len(descriptor.fieldIndex) == 0 && len(descriptor.mapField) == 0 {
+			continue
+		}
+
+		cells := filled
+		if r.isEmptyCell(descriptor, cell) {
+			cells = empty
+		}
+		if _, ok := cells[descriptor.componentName]; !ok {
+			cells[descriptor.componentName] = columnNum
+		}
+	}
+
+	for columnNum := range row {
+		descriptor := &r.colDescriptors[columnNum]
+		if first, ok := empty[descriptor.componentName]; ok && first == columnNum {
+			if other, ok := filled[descriptor.componentName]; ok {
+				return r.cellError(columnNum, fmt.Errorf("cell is empty but cell %q of the same component is not; want all or none of the cells of %s", r.header[other], descriptor.componentName))
+			}
+		}
+	}
+	return nil
+}
+
+// WithEmptyNumbersAsZero decodes empty cells of number fields as explicit
+// zeros, i.e., the field is set to 0 and its component is present, instead of
+// skipping the cell. This doesn't apply to fields decoded with codecs, unions,
+// or time layouts, nor to time.Duration fields.
+func WithEmptyNumbersAsZero() Option {
+	return func(o *options) {
+		o.emptyNumbersAsZero = true
+	}
+}
+
+// WithCompleteComponents requires all or none of the cells of each component
+// in a data row to be non-empty, so that partially filled components, which
+// are usually data inconsistencies, surface early. Otherwise, Read returns an
+// error that includes the row and column of the first empty cell.
+//
+// With the WithEmptyNumbersAsZero option, empty cells of number fields count as
+// non-empty. Presence columns, i.e., the columns with the bare name of a
+// component, e.g., 'MyComponent', are not checked.
+func WithCompleteComponents() Option {
+	return func(o *options) {
+		o.completeComponents = true
+	}
+}
+
+// missingRequiredColumns returns the errors of the fields of the components of
+// `T` that are tagged with the `required` option of the `csvstruct` tag but
+// have no column in the CSV header, whose width is `width`.
+func (r *Reader[T]) missingRequiredColumns(width int) []*ColumnError {
+	typ := reflect.TypeFor[T]()
+
+	var errs []*ColumnError
+	for i := 0; i < typ.NumField(); i++ {
+		component := typ.Field(i)
+		componentType, ok := componentStruct(component.Type)
+		if !ok || !component.IsExported() {
+			continue
+		}
+
+		for j := 0; j < componentType.NumField(); j++ {
+			field := componentType.Field(j)
+			if !field.IsExported() || !hasColumnOption(field, "required") {
+				continue
+			}
+
+			found := slices.ContainsFunc(r.colDescriptors, func(descriptor colDescriptor) bool {
+				return !descriptor.ignored && slices.Equal(descriptor.componentIndex, []int{i}) && slices.Equal(descriptor.fieldIndex, field.Index)
+			})
+			if !found {
+				errs = append(errs, &ColumnError{Column: width + 1, Name: columnName(component) + "." + columnName(field), Err: errors.New("required column is missing")})
+			}
+		}
+	}
+	return errs
+}
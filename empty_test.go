@@ -0,0 +1,85 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderEmptyNumbersAsZero(t *testing.T) {
+	const data = `Info.Name,Info.Class,Attributes.HP,Attributes.Damage
+Alex,Fighter,100,
+Mary,Queen,,
+`
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithEmptyNumbersAsZero())
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Prefab{
+		{Info: &Info{"Alex", "Fighter"}, Attributes: &Attributes{100, 0}},
+		{Info: &Info{"Mary", "Queen"}, Attributes: &Attributes{0, 0}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestReaderCompleteComponents(t *testing.T) {
+	const data = `Info.Name,Info.Class,Attributes.HP,Attributes.Damage
+Alex,Fighter,100,10
+Mary,Queen,,
+Jayden,Wizard,90,
+`
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithCompleteComponents())
+
+	for _, want := range []Prefab{
+		{Info: &Info{"Alex", "Fighter"}, Attributes: &Attributes{100, 10}},
+		{Info: &Info{"Mary", "Queen"}},
+	} {
+		var got Prefab
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Read() diff = %v", diff)
+		}
+	}
+
+	var got Prefab
+	err := reader.Read(&got)
+	var parseErr *csvstruct.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Read() err = %v; want %T", err, parseErr)
+	}
+	if parseErr.Line != 4 || parseErr.Name != "Attributes.Damage" {
+		t.Errorf("Read() err = %v; want error at line 4 in Attributes.Damage", err)
+	}
+}
+
+func TestReaderCompleteComponents_EmptyNumbersAsZero(t *testing.T) {
+	const data = `Info.Name,Info.Class,Attributes.HP,Attributes.Damage
+Jayden,Wizard,90,
+Mary,,,
+`
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithCompleteComponents(), csvstruct.WithEmptyNumbersAsZero())
+
+	var got Prefab
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(Prefab{Info: &Info{"Jayden", "Wizard"}, Attributes: &Attributes{90, 0}}, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+
+	want := `line 3, column 2 (Info.Class): cell is empty but cell "Info.Name" of the same component is not; want all or none of the cells of Info`
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
@@ -0,0 +1,87 @@
+package csvstruct
+
+import "time"
+
+// EventKind is the kind of an Event.
+type EventKind int
+
+const (
+	// EventTableStarted is emitted when the first row of a table, i.e., its CSV
+	// header, is read.
+	EventTableStarted EventKind = iota
+	// EventHeaderParsed is emitted when the CSV header of a table is valid and
+	// its column descriptors are created.
+	EventHeaderParsed
+	// EventRowDecoded is emitted when a data row is decoded.
+	EventRowDecoded
+	// EventRowSkipped is emitted when a row is read but not decoded, e.g., a
+	// section row before a CSV header.
+	EventRowSkipped
+	// EventTableEnded is emitted when a table ends, i.e., at the end of the CSV
+	// data or at a section row.
+	EventTableEnded
+	// EventWarning is emitted for problems that don't stop decoding.
+	EventWarning
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventTableStarted:
+		return "TableStarted"
+	case EventHeaderParsed:
+		return "HeaderParsed"
+	case EventRowDecoded:
+		return "RowDecoded"
+	case EventRowSkipped:
+		return "RowSkipped"
+	case EventTableEnded:
+		return "TableEnded"
+	case EventWarning:
+		return "Warning"
+	}
+	return "Unknown"
+}
+
+// Event describes progress of a Reader, so that tools, e.g., editors,
+// importers, and linters, can be built on top of a single decoding pass.
+type Event struct {
+	Kind EventKind
+	// Line of the row that caused the event, or 0 for events at the end of the
+	// CSV data.
+	Line int
+	// Name of the current section. Only used with the WithSections option.
+	Section string
+	// CSV header. Only used by EventHeaderParsed.
+	Header []string
+	// Pointer to the decoded value of type `T`. Only used by EventRowDecoded.
+	// It's only valid during the call to the observer.
+	Row any
+	// Reason of the event. Only used by EventRowSkipped and EventWarning.
+	Err error
+	// Time that it took to decode the row. Only used by EventRowDecoded with
+	// the WithRowTiming option.
+	Duration time.Duration
+}
+
+// Observer is called by a Reader for each Event, synchronously, in the order in
+// which the events happen.
+type Observer func(Event)
+
+// WithObserver subscribes the given observer to the events of the reader.
+// This option can be given multiple times to subscribe multiple observers.
+func WithObserver(observer Observer) Option {
+	return func(o *options) {
+		o.observers = append(o.observers, observer)
+	}
+}
+
+// emit records the given event in the statistics of the current table and
+// calls the observers with it.
+func (r *Reader[T]) emit(event Event) {
+	event.Section = r.section
+	r.stats.record(event)
+
+	for _, observer := range r.options.observers {
+		observer(event)
+	}
+}
@@ -0,0 +1,83 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderObserver(t *testing.T) {
+	const data = `[Heroes]
+Info.Name
+Alex
+Jayden
+[Villains]
+Info.Name
+Mary
+`
+
+	var got []string
+	observer := func(event csvstruct.Event) {
+		got = append(got, fmt.Sprintf("%d %s %s", event.Line, event.Kind, event.Section))
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithSections(), csvstruct.WithObserver(observer))
+	for {
+		var prefab Prefab
+		err := reader.Read(&prefab)
+		if err == io.EOF {
+			break
+		}
+		if err == csvstruct.ErrEndOfSection {
+			reader.Clear()
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+	}
+
+	want := []string{
+		"1 RowSkipped Heroes",
+		"2 TableStarted Heroes",
+		"2 HeaderParsed Heroes",
+		"3 RowDecoded Heroes",
+		"4 RowDecoded Heroes",
+		"5 TableEnded Heroes",
+		"6 TableStarted Villains",
+		"6 HeaderParsed Villains",
+		"7 RowDecoded Villains",
+		"0 TableEnded Villains",
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("events diff = %v", diff)
+	}
+}
+
+func TestReaderObserver_Row(t *testing.T) {
+	var got []string
+	observer := func(event csvstruct.Event) {
+		if event.Kind == csvstruct.EventRowDecoded {
+			got = append(got, event.Row.(*Prefab).Info.Name)
+		}
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)), csvstruct.WithObserver(observer))
+	for {
+		var prefab Prefab
+		if err := reader.Read(&prefab); err != nil {
+			break
+		}
+	}
+
+	want := []string{"Alex", "Jayden", "Mary", "Player"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("events diff = %v", diff)
+	}
+}
@@ -0,0 +1,104 @@
+package csvstruct
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrDuplicateKey is returned by RejectDuplicates.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// DuplicateFunc handles a row whose key is the same as the key of a row that
+// was read before. It's given the row stored for that key, which it can update,
+// and the new row. If it returns an error, reading stops with that error.
+//
+// KeepFirst, KeepLast, and RejectDuplicates are common duplicate functions. A
+// custom duplicate function can merge rows.
+type DuplicateFunc[T any] func(existing *T, row T) error
+
+// KeepFirst is a DuplicateFunc that keeps the first row with a given key and
+// ignores the subsequent ones.
+func KeepFirst[T any](existing *T, row T) error {
+	return nil
+}
+
+// KeepLast is a DuplicateFunc that keeps the last row with a given key.
+func KeepLast[T any](existing *T, row T) error {
+	*existing = row
+	return nil
+}
+
+// RejectDuplicates is a DuplicateFunc that returns ErrDuplicateKey.
+func RejectDuplicates[T any](existing *T, row T) error {
+	return ErrDuplicateKey
+}
+
+// ReadGrouped reads all the remaining rows of the current table and groups
+// them by the key computed by `keyFn`. Within each group, rows are in the same
+// order as in the CSV data.
+//
+// This is useful for one-to-many tables, e.g., all the loot entries of each
+// enemy.
+//
+// This is a function rather than a method of Reader because methods can't have
+// type parameters.
+func ReadGrouped[T any, K comparable](r *Reader[T], keyFn func(*T) K) (map[K][]T, error) {
+	groups := map[K][]T{}
+	for {
+		var t T
+		err := r.Read(&t)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		key := keyFn(&t)
+		groups[key] = append(groups[key], t)
+	}
+
+	return groups, nil
+}
+
+// ReadIndexed reads all the remaining rows of the current table and indexes
+// them by the key computed by `keyFn`.
+//
+// Rows with the same key as a previous row are handled by `onDuplicate`, e.g.,
+// KeepFirst, KeepLast, RejectDuplicates, or a custom function that merges rows.
+// If `onDuplicate` is nil, then RejectDuplicates is used.
+//
+// This is a function rather than a method of Reader because methods can't have
+// type parameters.
+func ReadIndexed[T any, K comparable](r *Reader[T], keyFn func(*T) K, onDuplicate DuplicateFunc[T]) (map[K]T, error) {
+	if onDuplicate == nil {
+		onDuplicate = RejectDuplicates[T]
+	}
+
+	index := map[K]T{}
+	for {
+		var t T
+		err := r.Read(&t)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		key := keyFn(&t)
+		existing, ok := index[key]
+		if !ok {
+			index[key] = t
+			continue
+		}
+
+		if err := onDuplicate(&existing, t); err != nil {
+			return nil, fmt.Errorf("row with key %v: %w", key, err)
+		}
+		index[key] = existing
+	}
+
+	return index, nil
+}
@@ -0,0 +1,111 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Drop struct {
+	Enemy  string
+	Item   string
+	Chance float64
+}
+
+type Loot struct {
+	Drop *Drop
+}
+
+func lootEnemy(l *Loot) string { return l.Drop.Enemy }
+
+const lootData = `Drop.Enemy,Drop.Item,Drop.Chance
+Goblin,Dagger,0.5
+Orc,Axe,0.25
+Goblin,Coin,1
+`
+
+func TestReadGrouped(t *testing.T) {
+	want := map[string][]Loot{
+		"Goblin": {
+			{&Drop{"Goblin", "Dagger", 0.5}},
+			{&Drop{"Goblin", "Coin", 1}},
+		},
+		"Orc": {
+			{&Drop{"Orc", "Axe", 0.25}},
+		},
+	}
+
+	reader := csvstruct.NewReader[Loot](csv.NewReader(strings.NewReader(lootData)))
+
+	got, err := csvstruct.ReadGrouped(reader, lootEnemy)
+	if err != nil {
+		t.Fatalf("ReadGrouped() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("ReadGrouped() diff = %v", diff)
+	}
+}
+
+func TestReadIndexed(t *testing.T) {
+	mergeChances := func(existing *Loot, row Loot) error {
+		existing.Drop.Chance += row.Drop.Chance
+		return nil
+	}
+
+	tests := []struct {
+		name        string
+		onDuplicate csvstruct.DuplicateFunc[Loot]
+		want        map[string]Loot
+	}{
+		{
+			"KeepFirst",
+			csvstruct.KeepFirst[Loot],
+			map[string]Loot{
+				"Goblin": {&Drop{"Goblin", "Dagger", 0.5}},
+				"Orc":    {&Drop{"Orc", "Axe", 0.25}},
+			},
+		},
+		{
+			"KeepLast",
+			csvstruct.KeepLast[Loot],
+			map[string]Loot{
+				"Goblin": {&Drop{"Goblin", "Coin", 1}},
+				"Orc":    {&Drop{"Orc", "Axe", 0.25}},
+			},
+		},
+		{
+			"Merge",
+			mergeChances,
+			map[string]Loot{
+				"Goblin": {&Drop{"Goblin", "Dagger", 1.5}},
+				"Orc":    {&Drop{"Orc", "Axe", 0.25}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		reader := csvstruct.NewReader[Loot](csv.NewReader(strings.NewReader(lootData)))
+
+		got, err := csvstruct.ReadIndexed(reader, lootEnemy, test.onDuplicate)
+		if err != nil {
+			t.Fatalf("%s: ReadIndexed() err = %v; want %v", test.name, err, nil)
+		}
+
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("%s: ReadIndexed() diff = %v", test.name, diff)
+		}
+	}
+}
+
+func TestReadIndexed_RejectDuplicates(t *testing.T) {
+	reader := csvstruct.NewReader[Loot](csv.NewReader(strings.NewReader(lootData)))
+
+	if _, err := csvstruct.ReadIndexed(reader, lootEnemy, nil); !errors.Is(err, csvstruct.ErrDuplicateKey) {
+		t.Fatalf("ReadIndexed() err = %v; want %v", err, csvstruct.ErrDuplicateKey)
+	}
+}
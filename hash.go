@@ -0,0 +1,54 @@
+package csvstruct
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"slices"
+	"strings"
+)
+
+// hashRow returns the hash of the given data row. The hash is computed over
+// the non-empty cells, in the order of the qualified names of their columns,
+// so that it doesn't depend on the order of the columns or on the presence of
+// empty columns.
+func (r *Reader[T]) hashRow(row []string) uint64 {
+	columns := make([]int, 0, len(row))
+	for columnNum, cell := range row {
+		if len(cell) > 0 {
+			columns = append(columns, columnNum)
+		}
+	}
+	slices.SortFunc(columns, func(a, b int) int {
+		return strings.Compare(r.colDescriptors[a].qualName(), r.colDescriptors[b].qualName())
+	})
+
+	hash := fnv.New64a()
+	for _, columnNum := range columns {
+		// Strings are length-prefixed so that different rows can't produce the
+		// same sequence of bytes.
+		for _, s := range []string{r.colDescriptors[columnNum].qualName(), row[columnNum]} {
+			hash.Write(binary.AppendUvarint(nil, uint64(len(s))))
+			hash.Write([]byte(s))
+		}
+	}
+	return hash.Sum64()
+}
+
+// RowHash returns the hash of the most recently decoded row. Only used with
+// the WithRowHash option.
+func (r *Reader[T]) RowHash() uint64 {
+	return r.rowHash
+}
+
+// WithRowHash computes a hash of each decoded row, which is returned by
+// Reader.RowHash, e.g., so that sync tools can detect changed rows without
+// comparing them field by field.
+//
+// The hash is a 64-bit FNV-1a hash over the qualified names and the contents of
+// the non-empty cells of the row. It's stable across runs and it doesn't
+// depend on the order of the columns or on the presence of empty columns.
+func WithRowHash() Option {
+	return func(o *options) {
+		o.rowHash = true
+	}
+}
@@ -0,0 +1,59 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/jabolopes/csvstruct"
+)
+
+// readRowHashes returns the row hashes of the given CSV data.
+func readRowHashes(t *testing.T, data string) []uint64 {
+	t.Helper()
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithRowHash())
+
+	var hashes []uint64
+	for {
+		var prefab Prefab
+		if err := reader.Read(&prefab); err != nil {
+			break
+		}
+		hashes = append(hashes, reader.RowHash())
+	}
+	return hashes
+}
+
+func TestWithRowHash(t *testing.T) {
+	got := readRowHashes(t, testData)
+	if len(got) != 4 {
+		t.Fatalf("len(hashes) = %d; want %d", len(got), 4)
+	}
+
+	// Same rows with the columns in a different order.
+	const reordered = `Attributes.Damage,Attributes.HP,Player,Info.Class,Info.Name
+10,100,,Fighter,Alex
+20,90,,Wizard,Jayden
+,,,Queen,Mary
+,,0,,Player
+`
+	want := readRowHashes(t, reordered)
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("RowHash() row %d = %x; want %x", i, got[i], want[i])
+		}
+	}
+
+	for i := 1; i < len(got); i++ {
+		if got[i] == got[i-1] {
+			t.Errorf("RowHash() row %d = %x; want different hash than previous row", i, got[i])
+		}
+	}
+
+	// A changed cell changes the hash.
+	changed := readRowHashes(t, strings.Replace(testData, "Alex,Fighter,100", "Alex,Fighter,101", 1))
+	if changed[0] == got[0] {
+		t.Errorf("RowHash() of changed row = %x; want different hash", changed[0])
+	}
+}
@@ -0,0 +1,51 @@
+package csvstruct
+
+import (
+	"slices"
+	"strings"
+)
+
+// normalizeColumn returns the name of the CSV header column `column` that is
+// matched against `T`, after applying the normalizer given with
+// WithHeaderNormalizer and, with the WithCaseInsensitiveHeaders option,
+// replacing it with the column of `schema` or of the schema given with
+// WithSchema that is equal to it ignoring case, if any.
+func (o *options) normalizeColumn(column string, schema Schema) string {
+	if o.headerNormalizer != nil {
+		column = o.headerNormalizer(column)
+	}
+	if !o.caseInsensitiveHeaders {
+		return column
+	}
+
+	qualName := o.mapping.qualName(column)
+	equalFold := func(c string) bool { return strings.EqualFold(c, qualName) }
+	if i := slices.IndexFunc(schema.Columns, equalFold); i >= 0 {
+		return schema.Columns[i]
+	}
+	if o.schema != nil {
+		if i := slices.IndexFunc(o.schema.Columns, equalFold); i >= 0 {
+			return o.schema.Columns[i]
+		}
+	}
+	return column
+}
+
+// WithHeaderNormalizer rewrites each CSV header column with `normalize` before
+// it's matched against `T`, e.g., so that the column 'Info HP' of an exported
+// spreadsheet is matched as 'Info.HP' without preprocessing the file. Reader
+// still reports errors and returns the CSV header with the original columns.
+func WithHeaderNormalizer(normalize func(string) string) Option {
+	return func(o *options) {
+		o.headerNormalizer = normalize
+	}
+}
+
+// WithCaseInsensitiveHeaders matches CSV header columns against `T` ignoring
+// case, e.g., the column 'info.hp' is matched as 'Info.HP'. Columns are
+// normalized first if WithHeaderNormalizer is also given.
+func WithCaseInsensitiveHeaders() Option {
+	return func(o *options) {
+		o.caseInsensitiveHeaders = true
+	}
+}
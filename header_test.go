@@ -0,0 +1,59 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderCaseInsensitiveHeaders(t *testing.T) {
+	const data = `info.name,INFO.CLASS,Attributes.hp
+Alex,Fighter,100
+`
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithCaseInsensitiveHeaders())
+
+	var got Prefab
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Prefab{Info: &Info{"Alex", "Fighter"}, Attributes: &Attributes{HP: 100}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+	if diff := cmp.Diff([]string{"info.name", "INFO.CLASS", "Attributes.hp"}, reader.Header()); diff != "" {
+		t.Errorf("Header() diff = %v", diff)
+	}
+}
+
+func TestReaderHeaderNormalizer(t *testing.T) {
+	const data = `Info Name, info class ,attributes hp
+Alex,Fighter,100
+`
+	normalize := func(column string) string {
+		return strings.ReplaceAll(strings.TrimSpace(column), " ", ".")
+	}
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithHeaderNormalizer(normalize), csvstruct.WithCaseInsensitiveHeaders())
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Prefab{{Info: &Info{"Alex", "Fighter"}, Attributes: &Attributes{HP: 100}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestReaderCaseInsensitiveHeaders_Disabled(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader("info.name\nAlex\n")))
+
+	var got Prefab
+	if err := reader.Read(&got); err == nil {
+		t.Errorf("Read() err = %v; want error", err)
+	}
+}
@@ -0,0 +1,109 @@
+package csvstruct
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Validator is implemented by rows and components that validate themselves
+// after they are decoded, e.g., to check that values are within bounds.
+//
+// It's detected whether Validate has a value or a pointer receiver, and whether
+// components are value or pointer fields.
+type Validator interface {
+	Validate() error
+}
+
+// AfterDecoder is implemented by rows and components that need to be updated
+// after they are decoded, e.g., to compute derived fields. Read calls
+// AfterDecode on each decoded row and each of its present components, and an
+// error is treated like a parse error.
+//
+// It's detected whether AfterDecode has a value or a pointer receiver, and
+// whether components are value or pointer fields.
+type AfterDecoder interface {
+	AfterDecode() error
+}
+
+// componentStruct returns the struct type of a component field of type `typ`
+// and true, or false if the field is not a component. Components are either
+// structs or pointers to structs.
+func componentStruct(typ reflect.Type) (reflect.Type, bool) {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ, typ.Kind() == reflect.Struct
+}
+
+// componentValue returns the struct value of the component `value`, which is
+// either a struct or a pointer to a struct, or an invalid value if the
+// component is a nil pointer.
+func componentValue(value reflect.Value) reflect.Value {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		return value.Elem()
+	}
+	return value
+}
+
+// callHooks calls `fn` on each present component of the row `t` and then on the
+// row itself. Components and the row are passed as pointers, so that `fn` sees
+// methods with value and pointer receivers. The errors of all calls are
+// returned together, and the errors of components are prefixed by the
+// component name.
+func callHooks[T any](t *T, fn func(any) error) error {
+	var errs []error
+
+	value := reflect.ValueOf(t).Elem()
+	if value.Kind() == reflect.Struct {
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if _, ok := componentStruct(field.Type); !ok {
+				continue
+			}
+
+			component := componentValue(value.Field(i))
+			if !component.IsValid() {
+				continue
+			}
+
+			if err := fn(component.Addr().Interface()); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", field.Name, err))
+			}
+		}
+	}
+
+	if err := fn(t); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateRow calls Validate on the row `t` and on each of its present
+// components that implement Validator.
+func validateRow[T any](t *T) error {
+	return callHooks(t, func(v any) error {
+		if validator, ok := v.(Validator); ok {
+			return validator.Validate()
+		}
+		return nil
+	})
+}
+
+// afterDecodeRow calls AfterDecode on the row `t` and on each of its present
+// components that implement AfterDecoder.
+func afterDecodeRow[T any](t *T) error {
+	return callHooks(t, func(v any) error {
+		if decoder, ok := v.(AfterDecoder); ok {
+			return decoder.AfterDecode()
+		}
+		return nil
+	})
+}
@@ -0,0 +1,123 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+// Name has a value receiver Validate.
+type Name struct {
+	First string
+	Last  string
+	Full  string
+}
+
+func (n Name) Validate() error {
+	if len(n.First) == 0 {
+		return errors.New("first name is required")
+	}
+	return nil
+}
+
+// AfterDecode has a pointer receiver.
+func (n *Name) AfterDecode() error {
+	n.Full = strings.TrimSpace(n.First + " " + n.Last)
+	return nil
+}
+
+// Health has a pointer receiver Validate.
+type Health struct {
+	HP    int
+	MaxHP int
+}
+
+func (h *Health) Validate() error {
+	if h.HP > h.MaxHP {
+		return errors.New("HP exceeds MaxHP")
+	}
+	return nil
+}
+
+// AfterDecode has a value receiver.
+func (h Health) AfterDecode() error {
+	if h.MaxHP < 0 {
+		return errors.New("MaxHP must not be negative")
+	}
+	return nil
+}
+
+// Character has a value component and a pointer component.
+type Character struct {
+	Name   Name
+	Health *Health
+}
+
+func TestHooks(t *testing.T) {
+	const data = `Name.First,Name.Last,Health.HP,Health.MaxHP
+Alex,Smith,10,20
+Mary,,,
+`
+
+	want := []Character{
+		{Name{"Alex", "Smith", "Alex Smith"}, &Health{10, 20}},
+		{Name{"Mary", "", "Mary"}, nil},
+	}
+
+	fsys := fstest.MapFS{"characters.csv": &fstest.MapFile{Data: []byte(data)}}
+
+	got, err := csvstruct.LoadAll[Character](fsys, "characters.csv")
+	if err != nil {
+		t.Fatalf("LoadAll() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("LoadAll() diff = %v", diff)
+	}
+}
+
+func TestHooks_Errors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{
+			`Name.First,Health.HP,Health.MaxHP
+,30,20
+`,
+			"line 2: Name: first name is required\nHealth: HP exceeds MaxHP",
+		},
+		{
+			`Name.First,Health.MaxHP
+Alex,-1
+`,
+			"line 2: Health: MaxHP must not be negative",
+		},
+	}
+
+	for _, test := range tests {
+		fsys := fstest.MapFS{"characters.csv": &fstest.MapFile{Data: []byte(test.data)}}
+
+		if _, err := csvstruct.LoadAll[Character](fsys, "characters.csv"); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("LoadAll() err = %v; want %q", err, test.want)
+		}
+	}
+}
+
+func TestReader_AfterDecode(t *testing.T) {
+	reader := csvstruct.NewReader[Character](csv.NewReader(strings.NewReader("Name.First,Name.Last\nAlex,Smith\n")))
+
+	var got Character
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	if got, want := got.Name.Full, "Alex Smith"; got != want {
+		t.Fatalf("Name.Full = %q; want %q", got, want)
+	}
+}
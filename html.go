@@ -0,0 +1,98 @@
+package csvstruct
+
+import (
+	"bufio"
+	"html"
+	"io"
+)
+
+// HTMLWriter writes component data as an HTML table, e.g., for web dashboards.
+// The table head contains the qualified names of the columns, like the CSV
+// header written by Writer, and the cells are escaped.
+//
+// This is thread compatible, i.e., it's safe for non-concurrent use and it can
+// be combined with external synchronization so it can be called concurrently.
+type HTMLWriter[T any] struct {
+	encoder[T]
+	// Underlying writer.
+	writer *bufio.Writer
+	// Whether the table head has been written.
+	hasHeader bool
+}
+
+// writeRow writes a table row whose cells are elements of the given tag, e.g.,
+// 'td'.
+func (w *HTMLWriter[T]) writeRow(tag string, cells []string) error {
+	w.writer.WriteString("<tr>")
+	for _, cell := range cells {
+		w.writer.WriteString("<" + tag + ">")
+		w.writer.WriteString(html.EscapeString(cell))
+		w.writer.WriteString("</" + tag + ">")
+	}
+	_, err := w.writer.WriteString("</tr>\n")
+	return err
+}
+
+// writeHead writes the start of the table, up to the start of the table body,
+// if it hasn't been written yet.
+func (w *HTMLWriter[T]) writeHead() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.hasHeader {
+		return nil
+	}
+
+	w.writer.WriteString("<table>\n<thead>\n")
+	w.writeRow("th", w.header())
+	if _, err := w.writer.WriteString("</thead>\n<tbody>\n"); err != nil {
+		return err
+	}
+
+	w.hasHeader = true
+	return nil
+}
+
+// Write writes `t` as a row of the table. Before the first row, the start of
+// the table and the table head are written.
+//
+// Writes are buffered, so Close must be called to end the table and to ensure
+// that it's written to the underlying io.Writer.
+func (w *HTMLWriter[T]) Write(t T) error {
+	if err := w.writeHead(); err != nil {
+		return err
+	}
+
+	record, err := w.encode(t)
+	if err != nil {
+		return err
+	}
+	return w.writeRow("td", record)
+}
+
+// Close ends the table and writes any buffered data to the underlying
+// io.Writer. If no rows were written, the table only has a head. It doesn't
+// close the underlying io.Writer.
+func (w *HTMLWriter[T]) Close() error {
+	if err := w.writeHead(); err != nil {
+		return err
+	}
+
+	w.writer.WriteString("</tbody>\n</table>\n")
+	return w.writer.Flush()
+}
+
+// NewHTMLWriter returns a new writer that writes an HTML table to `writer`. The
+// type `T` is the schema that is used to write the data.
+//
+// The writer can be configured with the same options as Writer, e.g.,
+// WithWriteComponents.
+//
+// If the type `T` is not a struct or if the options select components or
+// fields that `T` doesn't have, the first Write returns the error.
+func NewHTMLWriter[T any](writer io.Writer, opts ...WriterOption) *HTMLWriter[T] {
+	return &HTMLWriter[T]{
+		encoder: newEncoder[T](opts),
+		writer:  bufio.NewWriter(writer),
+	}
+}
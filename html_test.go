@@ -0,0 +1,50 @@
+package csvstruct_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func ExampleHTMLWriter() {
+	writer := csvstruct.NewHTMLWriter[Prefab](os.Stdout, csvstruct.WithWriteComponents("Info"))
+	for _, prefab := range testPrefabs[:2] {
+		if err := writer.Write(prefab); err != nil {
+			panic(err)
+		}
+	}
+	if err := writer.Write(Prefab{Info: &Info{"<Jordan>", "Rogue & Thief"}}); err != nil {
+		panic(err)
+	}
+	if err := writer.Close(); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// <table>
+	// <thead>
+	// <tr><th>Info.Name</th><th>Info.Class</th></tr>
+	// </thead>
+	// <tbody>
+	// <tr><td>Alex</td><td>Fighter</td></tr>
+	// <tr><td>Jayden</td><td>Wizard</td></tr>
+	// <tr><td>&lt;Jordan&gt;</td><td>Rogue &amp; Thief</td></tr>
+	// </tbody>
+	// </table>
+}
+
+func TestHTMLWriter_Empty(t *testing.T) {
+	var got strings.Builder
+	writer := csvstruct.NewHTMLWriter[Prefab](&got, csvstruct.WithWriteComponents("Player"))
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() err = %v; want %v", err, nil)
+	}
+
+	want := "<table>\n<thead>\n<tr><th>Player</th></tr>\n</thead>\n<tbody>\n</tbody>\n</table>\n"
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("Close() diff = %v", diff)
+	}
+}
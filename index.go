@@ -0,0 +1,200 @@
+package csvstruct
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// indexMagic identifies the index format. The last byte is the version.
+var indexMagic = []byte("CSVIDX\x00\x02")
+
+// Index contains the byte offsets of the rows of a table, which allows reading
+// the rows in any order without scanning the CSV data.
+type Index struct {
+	// CSV header of the table.
+	Header []string
+	// Units of the columns of Header, or nil if there is no units row. Only
+	// used with the WithUnits option.
+	Units []string
+	// Fingerprint of the schema, i.e., the type `T`, the CSV header, and the
+	// units.
+	Fingerprint string
+	// Offset in bytes of each data row of the table.
+	Offsets []int64
+}
+
+// BuildIndex reads all the remaining rows of the current table and returns an
+// index with their offsets. The rows are not parsed, only their offsets are
+// recorded.
+//
+// Like Read, this expects the first row to be the CSV header unless the header
+// was already read.
+func (r *Reader[T]) BuildIndex() (*Index, error) {
+	if r.permanentErr != nil {
+		return nil, r.permanentErr
+	}
+
+	if !r.hasDescriptors {
+		if err := r.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	index := &Index{
+		Header:      append([]string(nil), r.header...),
+		Units:       r.columnUnits(),
+		Fingerprint: r.schemaFingerprint(),
+	}
+
+	for {
+		offset := r.baseOffset + r.reader.InputOffset()
+		if _, err := r.reader.Read(); err != nil {
+			r.Clear()
+			r.permanentErr = err
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		index.Offsets = append(index.Offsets, offset)
+	}
+
+	return index, nil
+}
+
+// ReadRowAt reads the data row number `row` (starting at 0) of the table
+// described by `index` from the CSV data in `rs` into `t`.
+//
+// Returns an error if the type `T` is incompatible with the schema that was
+// used when the index was built.
+func ReadRowAt[T any](rs io.ReadSeeker, index *Index, row int, t *T, opts ...Option) error {
+	if row < 0 || row >= len(index.Offsets) {
+		return fmt.Errorf("row %d out of range [0, %d)", row, len(index.Offsets))
+	}
+
+	r, err := ResumeReader[T](rs, Checkpoint{Offset: index.Offsets[row], Header: index.Header, Units: index.Units, Fingerprint: index.Fingerprint}, opts...)
+	if err != nil {
+		return err
+	}
+
+	return r.Read(t)
+}
+
+// SaveIndex writes the index to `w` in a compact binary format that can be read
+// by LoadIndex.
+func SaveIndex(w io.Writer, index *Index) error {
+	buf := append([]byte(nil), indexMagic...)
+
+	buf = binary.AppendUvarint(buf, uint64(len(index.Fingerprint)))
+	buf = append(buf, index.Fingerprint...)
+
+	buf = binary.AppendUvarint(buf, uint64(len(index.Header)))
+	for _, column := range index.Header {
+		buf = binary.AppendUvarint(buf, uint64(len(column)))
+		buf = append(buf, column...)
+	}
+
+	// The number of units is stored plus one, or 0 if there is no units row,
+	// so that nil and empty units are distinguished.
+	if index.Units == nil {
+		buf = binary.AppendUvarint(buf, 0)
+	} else {
+		buf = binary.AppendUvarint(buf, uint64(len(index.Units))+1)
+	}
+	for _, unit := range index.Units {
+		buf = binary.AppendUvarint(buf, uint64(len(unit)))
+		buf = append(buf, unit...)
+	}
+
+	// Offsets are increasing, so they are stored as deltas.
+	buf = binary.AppendUvarint(buf, uint64(len(index.Offsets)))
+	var previous int64
+	for _, offset := range index.Offsets {
+		buf = binary.AppendVarint(buf, offset-previous)
+		previous = offset
+	}
+
+	_, err := w.Write(buf)
+	return err
+}
+
+// LoadIndex reads an index written by SaveIndex.
+func LoadIndex(r io.Reader) (*Index, error) {
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(indexMagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+	if string(magic) != string(indexMagic) {
+		return nil, errors.New("failed to read index: invalid format or version")
+	}
+
+	readString := func() (string, error) {
+		length, err := binary.ReadUvarint(br)
+		if err != nil {
+			return "", err
+		}
+
+		var b strings.Builder
+		if _, err := io.CopyN(&b, br, int64(length)); err != nil {
+			return "", err
+		}
+		return b.String(), nil
+	}
+
+	index := &Index{}
+
+	var err error
+	if index.Fingerprint, err = readString(); err != nil {
+		return nil, fmt.Errorf("failed to read index fingerprint: %w", err)
+	}
+
+	numColumns, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index header: %w", err)
+	}
+	for i := uint64(0); i < numColumns; i++ {
+		column, err := readString()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read index header: %w", err)
+		}
+		index.Header = append(index.Header, column)
+	}
+
+	numUnits, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index units: %w", err)
+	}
+	if numUnits > 0 {
+		index.Units = []string{}
+	}
+	for i := uint64(1); i < numUnits; i++ {
+		unit, err := readString()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read index units: %w", err)
+		}
+		index.Units = append(index.Units, unit)
+	}
+
+	numOffsets, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index offsets: %w", err)
+	}
+	var offset int64
+	for i := uint64(0); i < numOffsets; i++ {
+		delta, err := binary.ReadVarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read index offsets: %w", err)
+		}
+		offset += delta
+		index.Offsets = append(index.Offsets, offset)
+	}
+
+	return index, nil
+}
@@ -0,0 +1,78 @@
+package csvstruct_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestIndex(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
+
+	index, err := reader.BuildIndex()
+	if err != nil {
+		t.Fatalf("BuildIndex() err = %v; want %v", err, nil)
+	}
+
+	var buf bytes.Buffer
+	if err := csvstruct.SaveIndex(&buf, index); err != nil {
+		t.Fatalf("SaveIndex() err = %v; want %v", err, nil)
+	}
+
+	loaded, err := csvstruct.LoadIndex(&buf)
+	if err != nil {
+		t.Fatalf("LoadIndex() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(index, loaded); diff != "" {
+		t.Fatalf("LoadIndex() diff = %v", diff)
+	}
+
+	want := []Prefab{
+		{&Info{"Player", ""}, nil, &Player{}},
+		{&Info{"Alex", "Fighter"}, &Attributes{100, 10}, nil},
+		{&Info{"Mary", "Queen"}, nil, nil},
+	}
+
+	for i, row := range []int{3, 0, 2} {
+		var got Prefab
+		if err := csvstruct.ReadRowAt(strings.NewReader(testData), loaded, row, &got); err != nil {
+			t.Fatalf("ReadRowAt(%d) err = %v; want %v", row, err, nil)
+		}
+
+		if diff := cmp.Diff(want[i], got); diff != "" {
+			t.Errorf("ReadRowAt(%d) diff = %v", row, diff)
+		}
+	}
+}
+
+func TestReadRowAt_Defaults(t *testing.T) {
+	opts := []csvstruct.Option{csvstruct.WithDefaultComponents("Attributes")}
+	reader := csvstruct.NewReader[Seeded](csv.NewReader(strings.NewReader(seededData)), opts...)
+
+	index, err := reader.BuildIndex()
+	if err != nil {
+		t.Fatalf("BuildIndex() err = %v; want %v", err, nil)
+	}
+
+	var got Seeded
+	if err := csvstruct.ReadRowAt(strings.NewReader(seededData), index, 1, &got, opts...); err != nil {
+		t.Fatalf("ReadRowAt() err = %v; want %v", err, nil)
+	}
+
+	want := Seeded{&Info{Name: "Mary"}, &Balance{100, 1.5, 2 * time.Second, "common"}, &Attributes{}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadRowAt() diff = %v", diff)
+	}
+}
+
+func TestLoadIndex_Invalid(t *testing.T) {
+	if _, err := csvstruct.LoadIndex(strings.NewReader("not an index")); err == nil {
+		t.Fatalf("LoadIndex() err = %v; want error", err)
+	}
+}
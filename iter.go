@@ -0,0 +1,33 @@
+//go:build go1.23
+
+package csvstruct
+
+import (
+	"io"
+	"iter"
+)
+
+// All returns an iterator over the remaining rows of the current table, e.g.,
+// 'for prefab, err := range reader.All()'. Each row is yielded with a nil
+// error. Iteration stops at the end of the table, without yielding io.EOF, or
+// after yielding the first read error with the zero value of `T`.
+func (r *Reader[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			var t T
+			err := r.Read(&t)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
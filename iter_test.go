@@ -0,0 +1,79 @@
+//go:build go1.23
+
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderAll(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
+
+	var got []Prefab
+	for prefab, err := range reader.All() {
+		if err != nil {
+			t.Fatalf("All() err = %v; want %v", err, nil)
+		}
+		got = append(got, prefab)
+	}
+
+	if diff := cmp.Diff(testPrefabs, got); diff != "" {
+		t.Errorf("All() diff = %v", diff)
+	}
+}
+
+func TestReaderAll_Break(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
+
+	var got []Prefab
+	for prefab, err := range reader.All() {
+		if err != nil {
+			t.Fatalf("All() err = %v; want %v", err, nil)
+		}
+		got = append(got, prefab)
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	if diff := cmp.Diff(testPrefabs[:2], got); diff != "" {
+		t.Errorf("All() diff = %v", diff)
+	}
+
+	// Reading continues with the remaining rows.
+	var prefab Prefab
+	if err := reader.Read(&prefab); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(testPrefabs[2], prefab); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+}
+
+func TestReaderAll_Error(t *testing.T) {
+	const data = `Info.Name,Attributes.HP
+Alex,100
+Jayden,lots
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)))
+
+	var rows int
+	var gotErr error
+	for _, err := range reader.All() {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		rows++
+	}
+
+	if rows != 1 || gotErr == nil {
+		t.Errorf("All() rows = %d, err = %v; want 1 row and an error", rows, gotErr)
+	}
+}
@@ -0,0 +1,107 @@
+package csvstruct
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotSorted is returned by JoinSorted when the rows of a reader are not
+// sorted by key.
+var ErrNotSorted = errors.New("rows are not sorted by key")
+
+// sortedReader reads rows that are sorted by key.
+type sortedReader[T any, K cmp.Ordered] struct {
+	reader *Reader[T]
+	keyFn  func(*T) K
+	// Most recently read row and its key. Only valid if `ok` is true.
+	row T
+	key K
+	// Whether a row was read, i.e., the end of the table was not reached.
+	ok bool
+}
+
+// next reads the next row, and checks that its key is not smaller than the
+// key of the previous row.
+func (s *sortedReader[T, K]) next() error {
+	var row T
+	err := s.reader.Read(&row)
+	if err == io.EOF {
+		s.ok = false
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	key := s.keyFn(&row)
+	if s.ok && key < s.key {
+		return fmt.Errorf("line %d: %w: key %v is after key %v", s.reader.fieldLine(0), ErrNotSorted, key, s.key)
+	}
+	s.row, s.key, s.ok = row, key, true
+	return nil
+}
+
+// JoinSorted merge-joins the rows of `a` and `b`, which must be sorted by the
+// keys computed by `keyA` and `keyB`, respectively, and calls `fn` with each
+// pair of rows that have the same key, in the order of `a`. Rows without a
+// matching row in the other reader are skipped, i.e., this is an inner join.
+//
+// Rows are streamed, i.e., neither reader is loaded into memory, e.g., to join
+// large fact and dimension tables. Only the rows of `b` with the current key
+// are kept in memory, so `b` should be the reader whose keys repeat the least,
+// e.g., the dimension table. Reading stops when either reader reaches the end
+// of its table.
+//
+// Returns an error that wraps ErrNotSorted if the keys of a reader decrease,
+// or the first error of a reader or of `fn`.
+func JoinSorted[A, B any, K cmp.Ordered](a *Reader[A], b *Reader[B], keyA func(*A) K, keyB func(*B) K, fn func(A, B) error) error {
+	left := &sortedReader[A, K]{reader: a, keyFn: keyA}
+	right := &sortedReader[B, K]{reader: b, keyFn: keyB}
+	if err := left.next(); err != nil {
+		return err
+	}
+	if err := right.next(); err != nil {
+		return err
+	}
+
+	// Rows of `b` whose key is `groupKey`.
+	var group []B
+	var groupKey K
+	hasGroup := false
+	for left.ok {
+		if !hasGroup || groupKey != left.key {
+			for right.ok && right.key < left.key {
+				if err := right.next(); err != nil {
+					return err
+				}
+			}
+
+			group = group[:0]
+			for right.ok && right.key == left.key {
+				group = append(group, right.row)
+				if err := right.next(); err != nil {
+					return err
+				}
+			}
+			groupKey = left.key
+			hasGroup = true
+
+			if len(group) == 0 && !right.ok {
+				return nil
+			}
+		}
+
+		for _, row := range group {
+			if err := fn(left.row, row); err != nil {
+				return err
+			}
+		}
+
+		if err := left.next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+const enemyData = `Info.Name,Attributes.HP
+Dragon,500
+Goblin,10
+Orc,30
+`
+
+func TestJoinSorted(t *testing.T) {
+	const data = `Drop.Enemy,Drop.Item,Drop.Chance
+Goblin,Dagger,0.5
+Goblin,Coin,1
+Orc,Axe,0.25
+Slime,Gel,1
+`
+	loot := csvstruct.NewReader[Loot](csv.NewReader(strings.NewReader(data)))
+	enemies := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(enemyData)))
+
+	type pair struct {
+		Item string
+		HP   int
+	}
+	var got []pair
+	err := csvstruct.JoinSorted(loot, enemies, lootEnemy, prefabName, func(l Loot, p Prefab) error {
+		got = append(got, pair{l.Drop.Item, p.Attributes.HP})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("JoinSorted() err = %v; want %v", err, nil)
+	}
+
+	want := []pair{{"Dagger", 10}, {"Coin", 10}, {"Axe", 30}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("JoinSorted() diff = %v", diff)
+	}
+}
+
+func TestJoinSorted_NotSorted(t *testing.T) {
+	loot := csvstruct.NewReader[Loot](csv.NewReader(strings.NewReader(lootData)))
+	enemies := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(enemyData)))
+
+	err := csvstruct.JoinSorted(loot, enemies, lootEnemy, prefabName, func(Loot, Prefab) error { return nil })
+	if !errors.Is(err, csvstruct.ErrNotSorted) {
+		t.Fatalf("JoinSorted() err = %v; want %v", err, csvstruct.ErrNotSorted)
+	}
+
+	want := "line 4: rows are not sorted by key: key Goblin is after key Orc"
+	if err.Error() != want {
+		t.Errorf("JoinSorted() err = %v; want %v", err, want)
+	}
+}
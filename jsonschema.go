@@ -0,0 +1,142 @@
+package csvstruct
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// jsonSchemaDialect is the JSON Schema dialect of the documents returned by
+// JSONSchemaFor.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is a JSON Schema document, or the subschema of a property.
+type jsonSchema struct {
+	Schema     string                 `json:"$schema,omitempty"`
+	Title      string                 `json:"title,omitempty"`
+	Type       string                 `json:"type,omitempty"`
+	Format     string                 `json:"format,omitempty"`
+	Pattern    string                 `json:"pattern,omitempty"`
+	MinLength  int                    `json:"minLength,omitempty"`
+	Minimum    any                    `json:"minimum,omitempty"`
+	Maximum    any                    `json:"maximum,omitempty"`
+	Enum       []any                  `json:"enum,omitempty"`
+	Default    any                    `json:"default,omitempty"`
+	Properties map[string]*jsonSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+}
+
+// jsonFieldSchema returns the subschema of the cells of the component field
+// `field` of the type `typ`.
+func jsonFieldSchema(typ reflect.Type, field reflect.StructField) *jsonSchema {
+	schema := &jsonSchema{}
+	codec := lookupCodec(nil, field.Type)
+	if codec == nil {
+		codec = newListCodec(nil, nil, field.Type, "")
+	}
+	if codec == nil && !isPatternField(field) {
+		codec = newMapCodec(nil, nil, field.Type, "", "")
+	}
+	switch {
+	case codec != nil:
+		schema.Type = "string"
+	case field.Type.Kind() == reflect.Interface:
+		// Union fields can contain any type.
+	case field.Type == timeType:
+		schema.Type = "string"
+		if _, layout := parseTag(field); len(layout) == 0 || layout == time.RFC3339 {
+			schema.Format = "date-time"
+		}
+	case field.Type == durationType:
+		schema.Type = "string"
+	case field.Type == reflect.PointerTo(typ):
+		schema.Type = "string"
+		schema.Pattern = "^@"
+	default:
+		switch field.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			schema.Type = "integer"
+			if bits := field.Type.Bits(); bits < 64 {
+				schema.Minimum = -(int64(1) << (bits - 1))
+				schema.Maximum = int64(1)<<(bits-1) - 1
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			schema.Type = "integer"
+			schema.Minimum = 0
+			if bits := field.Type.Bits(); bits < 64 {
+				schema.Maximum = uint64(1)<<bits - 1
+			}
+		case reflect.Float32, reflect.Float64:
+			schema.Type = "number"
+		case reflect.Bool:
+			schema.Type = "boolean"
+		case reflect.String:
+			schema.Type = "string"
+		}
+	}
+
+	if enum, ok := columnOptionValue(field, "enum"); ok {
+		for _, cell := range strings.Split(enum, "|") {
+			var value any = cell
+			if parsed, err := parseDefault(nil, nil, field.Type, cell); err == nil && schema.Type != "string" {
+				value = parsed.Interface()
+			}
+			schema.Enum = append(schema.Enum, value)
+		}
+	}
+	if cell, ok := columnOptionValue(field, "default"); ok {
+		schema.Default = cell
+		if value, err := parseDefault(nil, nil, field.Type, cell); err == nil && schema.Type != "string" {
+			schema.Default = value.Interface()
+		}
+	}
+	if hasColumnOption(field, "required") && schema.Type == "string" {
+		schema.MinLength = 1
+	}
+	return schema
+}
+
+// JSONSchemaFor returns a JSON Schema document that describes the data rows
+// that a Reader[T] accepts, e.g., so that web-based editors and external
+// validators can enforce the same rules as Reader.
+//
+// A data row is described as a JSON object whose properties are the columns of
+// SchemaFor[T], e.g., 'MyComponent.MyField', with their types, e.g.,
+// 'integer' with the range of the field's type, the values of the `enum`
+// option and the defaults of the `default` option of the `csvstruct` tag, and
+// the columns of the fields tagged with the `required` option as required
+// properties. Marker components are booleans, and fields with codecs, slices,
+// maps, durations, and times are strings.
+func JSONSchemaFor[T any]() ([]byte, error) {
+	typ := reflect.TypeFor[T]()
+	document := &jsonSchema{Schema: jsonSchemaDialect, Title: typ.Name(), Type: "object", Properties: map[string]*jsonSchema{}}
+	if typ.Kind() == reflect.Struct {
+		for i := 0; i < typ.NumField(); i++ {
+			component := typ.Field(i)
+			componentType, ok := componentStruct(component.Type)
+			if !component.IsExported() || !ok {
+				continue
+			}
+
+			if componentType.NumField() == 0 {
+				document.Properties[columnName(component)] = &jsonSchema{Type: "boolean"}
+				continue
+			}
+
+			for j := 0; j < componentType.NumField(); j++ {
+				field := componentType.Field(j)
+				if !field.IsExported() {
+					continue
+				}
+
+				qualName := columnName(component) + "." + columnName(field)
+				document.Properties[qualName] = jsonFieldSchema(typ, field)
+				if hasColumnOption(field, "required") {
+					document.Required = append(document.Required, qualName)
+				}
+			}
+		}
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
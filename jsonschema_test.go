@@ -0,0 +1,100 @@
+package csvstruct_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Relic struct {
+	Name   string `csvstruct:",required"`
+	Rarity string `csvstruct:",enum=common|rare|epic,default=common"`
+	Level  uint8  `csvstruct:"level,default=1"`
+	Weight float64
+}
+
+type Vault struct {
+	Relic  *Relic
+	Player *Player
+}
+
+type Gem struct {
+	Tier int `csvstruct:",enum=1|2|3"`
+}
+
+type Hoard struct {
+	Gem *Gem
+}
+
+func TestJSONSchemaFor(t *testing.T) {
+	got, err := csvstruct.JSONSchemaFor[Vault]()
+	if err != nil {
+		t.Fatalf("JSONSchemaFor() err = %v; want %v", err, nil)
+	}
+
+	want := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Vault",
+  "type": "object",
+  "properties": {
+    "Player": {
+      "type": "boolean"
+    },
+    "Relic.Name": {
+      "type": "string",
+      "minLength": 1
+    },
+    "Relic.Rarity": {
+      "type": "string",
+      "enum": [
+        "common",
+        "rare",
+        "epic"
+      ],
+      "default": "common"
+    },
+    "Relic.Weight": {
+      "type": "number"
+    },
+    "Relic.level": {
+      "type": "integer",
+      "minimum": 0,
+      "maximum": 255,
+      "default": 1
+    }
+  },
+  "required": [
+    "Relic.Name"
+  ]
+}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("JSONSchemaFor() diff = %v", diff)
+	}
+}
+
+func TestJSONSchemaFor_NumericEnum(t *testing.T) {
+	got, err := csvstruct.JSONSchemaFor[Hoard]()
+	if err != nil {
+		t.Fatalf("JSONSchemaFor() err = %v; want %v", err, nil)
+	}
+
+	want := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Hoard",
+  "type": "object",
+  "properties": {
+    "Gem.Tier": {
+      "type": "integer",
+      "enum": [
+        1,
+        2,
+        3
+      ]
+    }
+  }
+}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("JSONSchemaFor() diff = %v", diff)
+	}
+}
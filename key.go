@@ -0,0 +1,92 @@
+package csvstruct
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// keyField is a component field that is part of the key of a type.
+type keyField struct {
+	// Index of the component field in the type.
+	componentIndex int
+	// Index of the field in the component.
+	fieldIndex int
+}
+
+// keyFields returns the key fields of the components of `typ`, in the order of
+// the components and their fields.
+func keyFields(typ reflect.Type) []keyField {
+	var fields []keyField
+	for i := 0; i < typ.NumField(); i++ {
+		component := typ.Field(i)
+		if !component.IsExported() {
+			continue
+		}
+
+		componentType, ok := componentStruct(component.Type)
+		if !ok {
+			continue
+		}
+
+		for j := 0; j < componentType.NumField(); j++ {
+			if field := componentType.Field(j); field.IsExported() && hasTagOption(field, "key") {
+				fields = append(fields, keyField{i, j})
+			}
+		}
+	}
+	return fields
+}
+
+// joinKey joins the cells of a key with more than one field, quoting the cells
+// like CSV data, so that different keys are never joined into the same string.
+func joinKey(cells []string) string {
+	for i, cell := range cells {
+		if strings.ContainsAny(cell, ",\"\r\n") {
+			cells[i] = `"` + strings.ReplaceAll(cell, `"`, `""`) + `"`
+		}
+	}
+	return strings.Join(cells, ",")
+}
+
+// KeyFunc returns a function that computes the key of a row from the component
+// fields tagged with `csv:",key"`, e.g., 'Name string `csv:",key"`'. This
+// defines the key once on the type, and the function can be passed to the
+// functions that take a key function, e.g., ReadIndexed, ReadLinked,
+// DiffTables, or BuildTree.
+//
+// Key fields can be in different components, e.g., 'Zone.Zone' and
+// 'Spawn.Name', which together form a composite key. The key is the value of
+// the key field, or if there is more than one, the values of the key fields in
+// the order of the components and their fields, joined by ',' and quoted like
+// CSV data when necessary, e.g., 'Forest,Goblin'. This canonical form is
+// comparable and it's unambiguous. Fields of nil components are empty.
+//
+// Panics if the type `T` is not a struct or it doesn't have key fields.
+func KeyFunc[T any]() func(*T) string {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Errorf("type %s is not a struct", typ.String()))
+	}
+
+	fields := keyFields(typ)
+	if len(fields) == 0 {
+		panic(fmt.Errorf("type %s does not have key fields; want a component field tagged with `csv:\",key\"`", typ.String()))
+	}
+
+	return func(t *T) string {
+		cells := make([]string, len(fields))
+		value := reflect.ValueOf(t).Elem()
+		for i, field := range fields {
+			component := componentValue(value.Field(field.componentIndex))
+			if component.IsValid() {
+				cells[i] = fmt.Sprint(component.Field(field.fieldIndex).Interface())
+			}
+		}
+
+		if len(cells) == 1 {
+			return cells[0]
+		}
+		return joinKey(cells)
+	}
+}
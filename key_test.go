@@ -0,0 +1,113 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Catalog struct {
+	Vendor string `csv:",key"`
+	SKU    string `csv:",key"`
+	Price  int
+}
+
+type Listing struct {
+	Catalog *Catalog
+}
+
+type Zone struct {
+	Zone string `csv:",key"`
+}
+
+type Spawn struct {
+	Name  string `csv:",key"`
+	Count int
+}
+
+type ZoneSpawn struct {
+	Zone  *Zone
+	Spawn *Spawn
+}
+
+type Keyed struct {
+	Info *struct {
+		Name string `csv:",key"`
+	}
+}
+
+func TestKeyFunc(t *testing.T) {
+	const data = `Catalog.Vendor,Catalog.SKU,Catalog.Price
+Acme,A-1,10
+Acme,A-2,20
+"Big, Co",A-1,30
+`
+
+	reader := csvstruct.NewReader[Listing](csv.NewReader(strings.NewReader(data)))
+
+	got, err := csvstruct.ReadIndexed(reader, csvstruct.KeyFunc[Listing](), nil)
+	if err != nil {
+		t.Fatalf("ReadIndexed() err = %v; want %v", err, nil)
+	}
+
+	want := map[string]Listing{
+		"Acme,A-1":      {&Catalog{"Acme", "A-1", 10}},
+		"Acme,A-2":      {&Catalog{"Acme", "A-2", 20}},
+		`"Big, Co",A-1`: {&Catalog{"Big, Co", "A-1", 30}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadIndexed() diff = %v", diff)
+	}
+}
+
+func TestKeyFunc_SingleField(t *testing.T) {
+	keyFn := csvstruct.KeyFunc[Keyed]()
+
+	row := Keyed{}
+	if got := keyFn(&row); got != "" {
+		t.Errorf("KeyFunc()(%v) = %q; want %q", row, got, "")
+	}
+
+	row.Info = &struct {
+		Name string `csv:",key"`
+	}{"Goblin"}
+	if got := keyFn(&row); got != "Goblin" {
+		t.Errorf("KeyFunc()(%v) = %q; want %q", row, got, "Goblin")
+	}
+}
+
+func TestKeyFunc_NoKey(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("KeyFunc() did not panic; want panic")
+		}
+	}()
+
+	csvstruct.KeyFunc[Prefab]()
+}
+
+func TestKeyFunc_AcrossComponents(t *testing.T) {
+	const data = `Zone.Zone,Spawn.Name,Spawn.Count
+Forest,Goblin,3
+Cave,Goblin,5
+Forest,Goblin,4
+`
+
+	reader := csvstruct.NewReader[ZoneSpawn](csv.NewReader(strings.NewReader(data)))
+
+	got, err := csvstruct.ReadIndexed(reader, csvstruct.KeyFunc[ZoneSpawn](), csvstruct.KeepLast[ZoneSpawn])
+	if err != nil {
+		t.Fatalf("ReadIndexed() err = %v; want %v", err, nil)
+	}
+
+	want := map[string]ZoneSpawn{
+		"Forest,Goblin": {&Zone{"Forest"}, &Spawn{"Goblin", 4}},
+		"Cave,Goblin":   {&Zone{"Cave"}, &Spawn{"Goblin", 5}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadIndexed() diff = %v", diff)
+	}
+}
@@ -0,0 +1,116 @@
+package csvstruct
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// defaultListSeparator is the separator of the elements of list cells, unless
+// another one is given with WithListSeparator or WithWriteListSeparator.
+const defaultListSeparator = ";"
+
+// listCodec is the codec of slice fields, e.g., []int or []string, whose
+// elements are stored in a single cell separated by `separator`, e.g.,
+// 'sword;shield;potion'.
+type listCodec struct {
+	// Codec of the elements, or nil if they are decoded as numbers, bools, or
+	// strings.
+	elem Codec
+	// Codecs of the reader or writer, used to parse the elements.
+	codecs map[reflect.Type]Codec
+	// Parser of bool elements, or nil for the default true and false values.
+	parseBool func(string) (bool, error)
+	// Separator of the elements.
+	separator string
+}
+
+// newListCodec returns the codec of the slice type `typ`, whose elements are
+// separated by `separator` and whose bool elements are parsed with
+// `parseBool`, or nil if `typ` is not a slice or if its elements can't be
+// stored in cells, i.e., they are neither numbers, bools, strings, nor types
+// with codecs.
+func newListCodec(codecs map[reflect.Type]Codec, parseBool func(string) (bool, error), typ reflect.Type, separator string) Codec {
+	if typ.Kind() != reflect.Slice {
+		return nil
+	}
+
+	codec := listCodec{codecs: codecs, parseBool: parseBool, separator: cmp.Or(separator, defaultListSeparator)}
+	codec.elem = lookupCodec(codecs, typ.Elem())
+	if codec.elem == nil && (typ.Elem() == timeType || !isWritableField(typ.Elem())) {
+		return nil
+	}
+	return codec
+}
+
+// Decode splits `cell` at the separator and parses each element, after
+// removing its leading and trailing spaces, e.g., 'sword; shield' decodes as
+// ["sword", "shield"].
+func (c listCodec) Decode(cell string, dst reflect.Value) error {
+	elems := strings.Split(cell, c.separator)
+	list := reflect.MakeSlice(dst.Type(), len(elems), len(elems))
+	for i, elem := range elems {
+		value, err := parseDefault(c.codecs, c.parseBool, dst.Type().Elem(), strings.TrimSpace(elem))
+		if err != nil {
+			return fmt.Errorf("element %d of %q: %w", i, cell, err)
+		}
+		list.Index(i).Set(value)
+	}
+	dst.Set(list)
+	return nil
+}
+
+// checkEncodedElem returns an error if the encoded element `elem` of a list or
+// map cell wouldn't decode as itself, i.e., if it contains any of the
+// `separators` or if it has leading or trailing spaces, which are removed when
+// decoding.
+func checkEncodedElem(elem string, separators ...string) error {
+	for _, separator := range separators {
+		if strings.Contains(elem, separator) {
+			return fmt.Errorf("%q contains the separator %q", elem, separator)
+		}
+	}
+	if strings.TrimSpace(elem) != elem {
+		return fmt.Errorf("%q has leading or trailing spaces", elem)
+	}
+	return nil
+}
+
+// Encode formats the elements of `src` and joins them with the separator. Nil
+// and empty slices are encoded as empty cells.
+//
+// Returns an error if an element contains the separator or has leading or
+// trailing spaces, since it would decode differently.
+func (c listCodec) Encode(src reflect.Value) (string, error) {
+	elems := make([]string, src.Len())
+	for i := range elems {
+		elem, err := encodeElem(c.elem, src.Index(i))
+		if err == nil {
+			err = checkEncodedElem(elem, c.separator)
+		}
+		if err != nil {
+			return "", fmt.Errorf("element %d: %w", i, err)
+		}
+		elems[i] = elem
+	}
+	return strings.Join(elems, c.separator), nil
+}
+
+// WithListSeparator sets the separator of the elements of the cells of slice
+// fields, e.g., []int or []string, which is ';' by default, e.g., the cell
+// 'sword;shield;potion' decodes as ["sword", "shield", "potion"]. Empty cells
+// decode as nil slices.
+func WithListSeparator(separator string) Option {
+	return func(o *options) {
+		o.listSeparator = separator
+	}
+}
+
+// WithWriteListSeparator sets the separator of the elements of the cells of
+// slice fields, which is ';' by default. See WithListSeparator.
+func WithWriteListSeparator(separator string) WriterOption {
+	return func(o *writerOptions) {
+		o.listSeparator = separator
+	}
+}
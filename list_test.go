@@ -0,0 +1,144 @@
+package csvstruct_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Pack struct {
+	Items  []string
+	Counts []int
+	Rolls  []csvstruct.Dice
+}
+
+type Stash struct {
+	Pack *Pack
+}
+
+func TestReaderList(t *testing.T) {
+	const data = `Pack.Items,Pack.Counts,Pack.Rolls
+sword;shield;potion,1; 2;3,1d6;2d4
+,,
+`
+
+	reader := csvstruct.NewReader[Stash](csv.NewReader(strings.NewReader(data)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Stash{
+		{&Pack{
+			Items:  []string{"sword", "shield", "potion"},
+			Counts: []int{1, 2, 3},
+			Rolls:  []csvstruct.Dice{{Count: 1, Sides: 6}, {Count: 2, Sides: 4}},
+		}},
+		{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestReaderList_Invalid(t *testing.T) {
+	const data = `Pack.Counts
+1;two
+`
+
+	reader := csvstruct.NewReader[Stash](csv.NewReader(strings.NewReader(data)))
+
+	var got Stash
+	err := reader.Read(&got)
+	if err == nil || !strings.Contains(err.Error(), `element 1 of "1;two"`) {
+		t.Errorf("Read() err = %v; want element 1 error", err)
+	}
+}
+
+func TestWithListSeparator(t *testing.T) {
+	const data = `Pack.Items,Pack.Counts
+sword|shield,1|2
+`
+
+	reader := csvstruct.NewReader[Stash](csv.NewReader(strings.NewReader(data)), csvstruct.WithListSeparator("|"))
+
+	var got Stash
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Stash{&Pack{Items: []string{"sword", "shield"}, Counts: []int{1, 2}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+}
+
+func TestWriter_List(t *testing.T) {
+	rows := []Stash{
+		{&Pack{Items: []string{"sword", "shield"}, Counts: []int{1, 2}, Rolls: []csvstruct.Dice{{Count: 1, Sides: 6}}}},
+		{&Pack{}},
+	}
+
+	var buffer bytes.Buffer
+	writer := csvstruct.NewWriter[Stash](csv.NewWriter(&buffer), csvstruct.WithWriteListSeparator("|"))
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	const want = `Pack.Items,Pack.Counts,Pack.Rolls
+sword|shield,1|2,1d6
+,,
+`
+	if got := buffer.String(); got != want {
+		t.Errorf("Write() = %q; want %q", got, want)
+	}
+
+	reader := csvstruct.NewReader[Stash](csv.NewReader(&buffer), csvstruct.WithListSeparator("|"))
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(rows[:1], got[:1]); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestWriter_ListAmbiguousElements(t *testing.T) {
+	for _, items := range [][]string{{"a;b", "c"}, {"a", " c"}, {"a "}} {
+		writer := csvstruct.NewWriter[Stash](csv.NewWriter(io.Discard))
+		if err := writer.Write(Stash{&Pack{Items: items}}); err == nil {
+			t.Errorf("Write(%q) err = %v; want error", items, err)
+		}
+	}
+
+	// Elements that decode as themselves round-trip.
+	rows := []Stash{{&Pack{Items: []string{"long sword", "a,b"}}}}
+
+	var buffer bytes.Buffer
+	writer := csvstruct.NewWriter[Stash](csv.NewWriter(&buffer))
+	if err := writer.Write(rows[0]); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	got, err := csvstruct.NewReader[Stash](csv.NewReader(&buffer)).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(rows, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
@@ -0,0 +1,181 @@
+package csvstruct
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+)
+
+// tableInfo describes a table read by readRows.
+type tableInfo struct {
+	// Effective schema of the table.
+	schema Schema
+	// Fingerprint of the schema of the table. See Reader.SchemaFingerprint.
+	fingerprint string
+	// Number of data rows of the table.
+	rows int
+}
+
+// readRows reads and validates all the rows of the first table of the CSV data
+// in `reader`, and calls `fn` with each valid row and its line number.
+// Validation errors and errors returned by `fn` of all rows are returned
+// together. Returns the description of the table.
+func readRows[T any](reader io.Reader, fn func(line int, t T) error, opts ...Option) (tableInfo, error) {
+	var r *Reader[T]
+	var info tableInfo
+	observer := func(event Event) {
+		if event.Kind == EventHeaderParsed {
+			info.schema = r.effectiveSchema()
+			info.fingerprint = r.schemaFingerprint()
+		}
+	}
+	r = NewReader[T](csv.NewReader(reader), append(slices.Clip(opts), WithObserver(observer))...)
+
+	var errs []error
+	for {
+		var t T
+		err := r.Read(&t)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return tableInfo{}, err
+		}
+
+		info.rows++
+		line := r.fieldLine(0)
+		if err := validateRow(&t); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+
+		if err := fn(line, t); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+		}
+	}
+
+	return info, errors.Join(errs...)
+}
+
+// loadRows reads and validates all the rows of the first table of the CSV data
+// in `reader`. Validation errors of all rows are returned together.
+func loadRows[T any](reader io.Reader, opts ...Option) ([]T, error) {
+	var rows []T
+	_, err := readRows(reader, func(line int, t T) error {
+		rows = append(rows, t)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// LoadAll reads and validates all the rows of the first table of the CSV file
+// `name` in `fsys`.
+//
+// Rows and components that implement Validator are validated after they are
+// decoded. The rows are only returned if the whole file is decoded and
+// validated successfully, i.e., this never returns partially valid data. This
+// is important when reloading data, where a broken table must not replace a
+// good one.
+func LoadAll[T any](fsys fs.FS, name string, opts ...Option) ([]T, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	rows, err := loadRows[T](file, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %q: %w", name, err)
+	}
+	return rows, nil
+}
+
+// LoadIndexedFile reads and validates all the rows of the first table of the
+// CSV file `name` in `fsys`, and indexes them by the key computed by `keyFn`.
+//
+// Like LoadAll, the rows are only returned if the whole file is decoded and
+// validated successfully. Keys must be unique. Validation errors and duplicate
+// keys of all rows are returned together, each with its line number, and
+// duplicate keys wrap ErrDuplicateKey.
+func LoadIndexedFile[T any, K comparable](fsys fs.FS, name string, keyFn func(*T) K, opts ...Option) (map[K]T, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	index := map[K]T{}
+	lines := map[K]int{}
+	_, err = readRows(file, func(line int, t T) error {
+		key := keyFn(&t)
+		if first, ok := lines[key]; ok {
+			return fmt.Errorf("%w %v, first defined on line %d", ErrDuplicateKey, key, first)
+		}
+
+		index[key] = t
+		lines[key] = line
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %q: %w", name, err)
+	}
+	return index, nil
+}
+
+// FileSchema is the effective schema of a CSV file loaded by LoadFiles.
+type FileSchema struct {
+	// Name of the file.
+	Name string
+	// Columns of the CSV header of the file that are decoded, in the order of
+	// the CSV header.
+	Schema Schema
+}
+
+// LoadFiles reads and validates all the rows of the first table of each of the
+// CSV files `names` in `fsys`, and concatenates them in the order of `names`.
+//
+// The CSV headers of the files don't need to be identical, e.g., they can be
+// different subsets of the columns of `T`, in which case the fields of the
+// columns that a file doesn't have are empty. The effective schema of each file
+// is returned, in the order of `names`, e.g., to report which columns each file
+// provides.
+//
+// Like LoadAll, the rows are only returned if all the files are decoded and
+// validated successfully.
+func LoadFiles[T any](fsys fs.FS, names []string, opts ...Option) ([]T, []FileSchema, error) {
+	var rows []T
+	schemas := make([]FileSchema, 0, len(names))
+	for _, name := range names {
+		schema, err := loadFile(fsys, name, func(line int, t T) error {
+			rows = append(rows, t)
+			return nil
+		}, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		schemas = append(schemas, FileSchema{name, schema})
+	}
+	return rows, schemas, nil
+}
+
+// loadFile reads and validates all the rows of the first table of the CSV file
+// `name` in `fsys`, like readRows.
+func loadFile[T any](fsys fs.FS, name string, fn func(line int, t T) error, opts ...Option) (Schema, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return Schema{}, err
+	}
+	defer file.Close()
+
+	info, err := readRows(file, fn, opts...)
+	if err != nil {
+		return Schema{}, fmt.Errorf("failed to load %q: %w", name, err)
+	}
+	return info.schema, nil
+}
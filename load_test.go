@@ -0,0 +1,192 @@
+package csvstruct_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Stats struct {
+	HP int
+}
+
+func (s *Stats) Validate() error {
+	if s.HP <= 0 {
+		return errors.New("HP must be positive")
+	}
+	return nil
+}
+
+type Unit struct {
+	Info  *Info
+	Stats *Stats
+}
+
+func TestLoadAll(t *testing.T) {
+	fsys := fstest.MapFS{"units.csv": &fstest.MapFile{Data: []byte(`Info.Name,Stats.HP
+Alex,100
+Mary,
+`)}}
+
+	want := []Unit{
+		{&Info{"Alex", ""}, &Stats{100}},
+		{&Info{"Mary", ""}, nil},
+	}
+
+	got, err := csvstruct.LoadAll[Unit](fsys, "units.csv")
+	if err != nil {
+		t.Fatalf("LoadAll() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("LoadAll() diff = %v", diff)
+	}
+}
+
+func TestLoadAll_Errors(t *testing.T) {
+	tests := []struct {
+		data string
+		want []string
+	}{
+		{
+			`Info.Name,Stats.HP
+Alex,100
+Jayden,-1
+Mary,0
+`,
+			[]string{"line 3: Stats: HP must be positive", "line 4: Stats: HP must be positive"},
+		},
+		{
+			`Info.Name,Stats.HP
+Alex,100
+Jayden,many
+`,
+			[]string{"invalid syntax"},
+		},
+	}
+
+	for _, test := range tests {
+		fsys := fstest.MapFS{"units.csv": &fstest.MapFile{Data: []byte(test.data)}}
+
+		got, err := csvstruct.LoadAll[Unit](fsys, "units.csv")
+		if got != nil {
+			t.Errorf("LoadAll() = %v; want %v", got, nil)
+		}
+
+		for _, want := range test.want {
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("LoadAll() err = %v; want %q", err, want)
+			}
+		}
+	}
+}
+
+func unitName(unit *Unit) string {
+	return unit.Info.Name
+}
+
+func TestLoadIndexedFile(t *testing.T) {
+	fsys := fstest.MapFS{"units.csv": &fstest.MapFile{Data: []byte(`Info.Name,Stats.HP
+Alex,100
+Mary,
+`)}}
+
+	want := map[string]Unit{
+		"Alex": {&Info{"Alex", ""}, &Stats{100}},
+		"Mary": {&Info{"Mary", ""}, nil},
+	}
+
+	got, err := csvstruct.LoadIndexedFile(fsys, "units.csv", unitName)
+	if err != nil {
+		t.Fatalf("LoadIndexedFile() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("LoadIndexedFile() diff = %v", diff)
+	}
+}
+
+func TestLoadIndexedFile_Errors(t *testing.T) {
+	fsys := fstest.MapFS{"units.csv": &fstest.MapFile{Data: []byte(`Info.Name,Stats.HP
+Alex,100
+Jayden,-1
+Alex,90
+Mary,10
+Mary,20
+`)}}
+
+	got, err := csvstruct.LoadIndexedFile(fsys, "units.csv", unitName)
+	if got != nil {
+		t.Errorf("LoadIndexedFile() = %v; want %v", got, nil)
+	}
+
+	if !errors.Is(err, csvstruct.ErrDuplicateKey) {
+		t.Errorf("LoadIndexedFile() err = %v; want %v", err, csvstruct.ErrDuplicateKey)
+	}
+
+	for _, want := range []string{
+		"line 3: Stats: HP must be positive",
+		"line 4: duplicate key Alex, first defined on line 2",
+		"line 6: duplicate key Mary, first defined on line 5",
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadIndexedFile() err = %v; want %q", err, want)
+		}
+	}
+}
+
+func TestLoadFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"heroes.csv": &fstest.MapFile{Data: []byte(`Info.Name,Info.Class,Stats.HP
+Alex,Fighter,100
+`)},
+		"villains.csv": &fstest.MapFile{Data: []byte(`Stats.HP,Info.Name
+90,Jayden
+`)},
+		"extras.csv": &fstest.MapFile{Data: []byte(`Info.Name
+Mary
+`)},
+	}
+
+	rows, schemas, err := csvstruct.LoadFiles[Unit](fsys, []string{"heroes.csv", "villains.csv", "extras.csv"})
+	if err != nil {
+		t.Fatalf("LoadFiles() err = %v; want %v", err, nil)
+	}
+
+	wantRows := []Unit{
+		{&Info{"Alex", "Fighter"}, &Stats{100}},
+		{&Info{"Jayden", ""}, &Stats{90}},
+		{&Info{"Mary", ""}, nil},
+	}
+	if diff := cmp.Diff(wantRows, rows); diff != "" {
+		t.Errorf("LoadFiles() rows diff = %v", diff)
+	}
+
+	wantSchemas := []csvstruct.FileSchema{
+		{"heroes.csv", csvstruct.Schema{Columns: []string{"Info.Name", "Info.Class", "Stats.HP"}}},
+		{"villains.csv", csvstruct.Schema{Columns: []string{"Stats.HP", "Info.Name"}}},
+		{"extras.csv", csvstruct.Schema{Columns: []string{"Info.Name"}}},
+	}
+	if diff := cmp.Diff(wantSchemas, schemas); diff != "" {
+		t.Errorf("LoadFiles() schemas diff = %v", diff)
+	}
+}
+
+func TestLoadFiles_Errors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"heroes.csv": &fstest.MapFile{Data: []byte("Info.Name,Stats.HP\nAlex,100\n")},
+		"broken.csv": &fstest.MapFile{Data: []byte("Info.Name,Stats.HP\nJayden,0\n")},
+	}
+
+	rows, _, err := csvstruct.LoadFiles[Unit](fsys, []string{"heroes.csv", "broken.csv"})
+	if err == nil || !strings.HasPrefix(err.Error(), `failed to load "broken.csv": line 2: `) {
+		t.Errorf("LoadFiles() err = %v; want error in broken.csv", err)
+	}
+	if rows != nil {
+		t.Errorf("LoadFiles() = %v; want %v", rows, nil)
+	}
+}
@@ -0,0 +1,179 @@
+package csvstruct
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+)
+
+// ErrManifestMismatch is returned when a CSV file doesn't match its entry in a
+// manifest, e.g., because it's truncated or it was changed after the manifest
+// was built.
+var ErrManifestMismatch = errors.New("manifest mismatch")
+
+// ManifestFile describes a CSV file of a data bundle.
+type ManifestFile struct {
+	// Name of the file in the bundle.
+	Name string `json:"name"`
+	// Fingerprint of the schema of the first table of the file. See
+	// Reader.SchemaFingerprint.
+	Fingerprint string `json:"fingerprint"`
+	// Number of data rows of the first table of the file.
+	Rows int `json:"rows"`
+	// SHA-256 hash of the contents of the file, in hexadecimal.
+	Hash string `json:"hash"`
+}
+
+// Manifest describes the CSV files of a data bundle, e.g., the game data that
+// ships with a build, so that the bundle can be verified when it's loaded,
+// e.g., to catch truncated or mismatched files before the game boots.
+//
+// A manifest is usually built with BuildManifest when the bundle is created,
+// saved with WriteManifest, and loaded at runtime with LoadManifest.
+type Manifest struct {
+	Files []ManifestFile `json:"files"`
+}
+
+// file returns the entry of the file `name`, and whether it exists.
+func (m *Manifest) file(name string) (ManifestFile, bool) {
+	for _, file := range m.Files {
+		if file.Name == name {
+			return file, true
+		}
+	}
+	return ManifestFile{}, false
+}
+
+// readManifestFile reads and validates all the rows of the first table of the
+// CSV file `name` in `fsys`, like readRows, and describes the file.
+func readManifestFile[T any](fsys fs.FS, name string, fn func(line int, t T) error, opts ...Option) (ManifestFile, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	reader := io.TeeReader(file, hash)
+	info, err := readRows(reader, fn, opts...)
+	if err != nil {
+		return ManifestFile{}, fmt.Errorf("failed to load %q: %w", name, err)
+	}
+
+	// The contents after the first table are part of the hash.
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return ManifestFile{}, fmt.Errorf("failed to load %q: %w", name, err)
+	}
+
+	return ManifestFile{name, info.fingerprint, info.rows, hexHash(hash)}, nil
+}
+
+// hexHash returns the hash computed by `hash` in hexadecimal.
+func hexHash(hash hash.Hash) string {
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ManifestFileFor describes the CSV file `name` in `fsys`, whose first table
+// is read and validated with a Reader[T], like LoadAll, e.g., to build the
+// manifest of a bundle whose files have different types.
+func ManifestFileFor[T any](fsys fs.FS, name string, opts ...Option) (ManifestFile, error) {
+	return readManifestFile(fsys, name, func(int, T) error { return nil }, opts...)
+}
+
+// BuildManifest builds the manifest of the CSV files in `fsys` that match
+// `pattern`, e.g., 'units/*.csv', whose rows are of type `T`. See
+// ManifestFileFor.
+func BuildManifest[T any](fsys fs.FS, pattern string, opts ...Option) (Manifest, error) {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	var manifest Manifest
+	for _, name := range names {
+		file, err := ManifestFileFor[T](fsys, name, opts...)
+		if err != nil {
+			return Manifest{}, err
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+	return manifest, nil
+}
+
+// WriteManifest writes `manifest` in JSON format, which is loaded by
+// LoadManifest.
+func WriteManifest(w io.Writer, manifest Manifest) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(manifest)
+}
+
+// LoadManifest loads a manifest in JSON format, as written by WriteManifest.
+func LoadManifest(r io.Reader) (Manifest, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var manifest Manifest
+	if err := decoder.Decode(&manifest); err != nil {
+		return Manifest{}, fmt.Errorf("failed to load manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// VerifyBundle verifies that the contents of all the files of `manifest` in
+// `fsys` match their hashes, without decoding them, e.g., to verify a bundle
+// quickly before loading it. The problems of all the files are returned
+// together, and mismatches wrap ErrManifestMismatch.
+func VerifyBundle(fsys fs.FS, manifest Manifest) error {
+	var errs []error
+	for _, file := range manifest.Files {
+		data, err := fs.ReadFile(fsys, file.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		hash := sha256.New()
+		hash.Write(data)
+		if got := hexHash(hash); got != file.Hash {
+			errs = append(errs, fmt.Errorf("%w: file %q has hash %s; want %s", ErrManifestMismatch, file.Name, got, file.Hash))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadVerified reads and validates all the rows of the first table of the CSV
+// file `name` in `fsys`, like LoadAll, and verifies that the file matches its
+// entry in `manifest`, i.e., its hash, its number of rows, and the fingerprint
+// of its schema. Mismatches wrap ErrManifestMismatch, and the rows are only
+// returned if the file matches.
+func LoadVerified[T any](fsys fs.FS, name string, manifest Manifest, opts ...Option) ([]T, error) {
+	want, ok := manifest.file(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: file %q is not in the manifest", ErrManifestMismatch, name)
+	}
+
+	var rows []T
+	got, err := readManifestFile(fsys, name, func(line int, t T) error {
+		rows = append(rows, t)
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case got.Hash != want.Hash:
+		return nil, fmt.Errorf("%w: file %q has hash %s; want %s", ErrManifestMismatch, name, got.Hash, want.Hash)
+	case got.Rows != want.Rows:
+		return nil, fmt.Errorf("%w: file %q has %d rows; want %d", ErrManifestMismatch, name, got.Rows, want.Rows)
+	case got.Fingerprint != want.Fingerprint:
+		return nil, fmt.Errorf("%w: file %q has schema fingerprint %s; want %s", ErrManifestMismatch, name, got.Fingerprint, want.Fingerprint)
+	}
+	return rows, nil
+}
@@ -0,0 +1,91 @@
+package csvstruct_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func bundleFS() fstest.MapFS {
+	return fstest.MapFS{
+		"units/heroes.csv":   &fstest.MapFile{Data: []byte("Info.Name,Stats.HP\nAlex,100\nMary,90\n")},
+		"units/villains.csv": &fstest.MapFile{Data: []byte("Info.Name\nZoe\n")},
+	}
+}
+
+func TestManifest(t *testing.T) {
+	fsys := bundleFS()
+
+	manifest, err := csvstruct.BuildManifest[Unit](fsys, "units/*.csv")
+	if err != nil {
+		t.Fatalf("BuildManifest() err = %v; want %v", err, nil)
+	}
+
+	var names []string
+	var rows []int
+	for _, file := range manifest.Files {
+		names = append(names, file.Name)
+		rows = append(rows, file.Rows)
+	}
+	if diff := cmp.Diff([]string{"units/heroes.csv", "units/villains.csv"}, names); diff != "" {
+		t.Errorf("BuildManifest() names diff = %v", diff)
+	}
+	if diff := cmp.Diff([]int{2, 1}, rows); diff != "" {
+		t.Errorf("BuildManifest() rows diff = %v", diff)
+	}
+
+	var buf strings.Builder
+	if err := csvstruct.WriteManifest(&buf, manifest); err != nil {
+		t.Fatalf("WriteManifest() err = %v; want %v", err, nil)
+	}
+
+	loaded, err := csvstruct.LoadManifest(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("LoadManifest() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(manifest, loaded); diff != "" {
+		t.Errorf("LoadManifest() diff = %v", diff)
+	}
+
+	if err := csvstruct.VerifyBundle(fsys, loaded); err != nil {
+		t.Errorf("VerifyBundle() err = %v; want %v", err, nil)
+	}
+
+	got, err := csvstruct.LoadVerified[Unit](fsys, "units/heroes.csv", loaded)
+	if err != nil {
+		t.Fatalf("LoadVerified() err = %v; want %v", err, nil)
+	}
+
+	want := []Unit{{&Info{Name: "Alex"}, &Stats{100}}, {&Info{Name: "Mary"}, &Stats{90}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LoadVerified() diff = %v", diff)
+	}
+}
+
+func TestManifest_Mismatch(t *testing.T) {
+	manifest, err := csvstruct.BuildManifest[Unit](bundleFS(), "units/*.csv")
+	if err != nil {
+		t.Fatalf("BuildManifest() err = %v; want %v", err, nil)
+	}
+
+	// The last row of the file is truncated.
+	fsys := bundleFS()
+	fsys["units/heroes.csv"].Data = []byte("Info.Name,Stats.HP\nAlex,100\n")
+
+	if err := csvstruct.VerifyBundle(fsys, manifest); !errors.Is(err, csvstruct.ErrManifestMismatch) {
+		t.Errorf("VerifyBundle() err = %v; want %v", err, csvstruct.ErrManifestMismatch)
+	}
+
+	if _, err := csvstruct.LoadVerified[Unit](fsys, "units/heroes.csv", manifest); !errors.Is(err, csvstruct.ErrManifestMismatch) {
+		t.Errorf("LoadVerified() err = %v; want %v", err, csvstruct.ErrManifestMismatch)
+	}
+
+	// The schema of the file changed, e.g., because of a different type.
+	if _, err := csvstruct.LoadVerified[Prefab](bundleFS(), "units/villains.csv", manifest); err == nil || !strings.Contains(err.Error(), "schema fingerprint") {
+		t.Errorf("LoadVerified() err = %v; want schema fingerprint mismatch", err)
+	}
+}
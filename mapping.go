@@ -0,0 +1,66 @@
+package csvstruct
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Mapping adapts the columns of CSV data, e.g., exported by third-party tools,
+// to the components of a type, without changing the type.
+//
+// A mapping is usually loaded at runtime with LoadMapping and given to a
+// Reader with the WithMapping option.
+type Mapping struct {
+	// Columns renames CSV header columns to qualified names, e.g., the column
+	// 'HP' to 'Stats.Health'. Several columns can be renamed to the same
+	// qualified name, i.e., they are aliases. Columns that are not renamed keep
+	// their names.
+	Columns map[string]string `json:"columns"`
+	// Converters gives the names of the codecs, registered with
+	// RegisterNamedCodec, that decode the fields with the given qualified
+	// names, e.g., 'Stats.Health'. They take precedence over the codecs of the
+	// fields' types.
+	Converters map[string]string `json:"converters"`
+}
+
+// LoadMapping loads a mapping in JSON format, e.g.:
+//
+//	{
+//	  "columns": {"HP": "Stats.Health", "Hit Points": "Stats.Health"},
+//	  "converters": {"Stats.Health": "percent"}
+//	}
+func LoadMapping(r io.Reader) (Mapping, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var mapping Mapping
+	if err := decoder.Decode(&mapping); err != nil {
+		return Mapping{}, fmt.Errorf("failed to load mapping: %w", err)
+	}
+	return mapping, nil
+}
+
+// qualName returns the qualified name of the given CSV header column, after
+// renaming.
+func (m *Mapping) qualName(column string) string {
+	if qualName, ok := m.Columns[column]; ok {
+		return qualName
+	}
+	return column
+}
+
+// converter returns the named codec for the field with the given qualified
+// name, or nil if the mapping doesn't give one.
+func (m *Mapping) converter(qualName string) (Codec, error) {
+	name, ok := m.Converters[qualName]
+	if !ok {
+		return nil, nil
+	}
+
+	codec, ok := lookupNamedCodec(name)
+	if !ok {
+		return nil, fmt.Errorf("column %s has unknown converter %q; want a codec registered with RegisterNamedCodec", qualName, name)
+	}
+	return codec, nil
+}
@@ -0,0 +1,81 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+// percentCodec decodes percentages, e.g., '50%', into integers.
+type percentCodec struct{}
+
+func (percentCodec) Decode(cell string, dst reflect.Value) error {
+	number, err := strconv.Atoi(strings.TrimSuffix(cell, "%"))
+	if err != nil {
+		return err
+	}
+	dst.SetInt(int64(number))
+	return nil
+}
+
+func (percentCodec) Encode(src reflect.Value) (string, error) {
+	return strconv.FormatInt(src.Int(), 10) + "%", nil
+}
+
+func init() {
+	csvstruct.RegisterNamedCodec("percent", percentCodec{})
+}
+
+const testMapping = `{
+  "columns": {"Name": "Info.Name", "HP": "Attributes.HP", "Hit Points": "Attributes.HP"},
+  "converters": {"Attributes.HP": "percent"}
+}`
+
+func TestReaderMapping(t *testing.T) {
+	mapping, err := csvstruct.LoadMapping(strings.NewReader(testMapping))
+	if err != nil {
+		t.Fatalf("LoadMapping() err = %v; want %v", err, nil)
+	}
+
+	tests := []struct {
+		data string
+		want Prefab
+	}{
+		{"Name,HP\nAlex,50%\n", Prefab{Info: &Info{Name: "Alex"}, Attributes: &Attributes{HP: 50}}},
+		{"Name,Hit Points,Info.Class\nJordan,75%,Mage\n", Prefab{Info: &Info{"Jordan", "Mage"}, Attributes: &Attributes{HP: 75}}},
+	}
+
+	for _, test := range tests {
+		reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(test.data)), csvstruct.WithMapping(mapping))
+
+		var got Prefab
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("Read() diff = %v", diff)
+		}
+	}
+}
+
+func TestReaderMapping_UnknownConverter(t *testing.T) {
+	mapping := csvstruct.Mapping{Converters: map[string]string{"Attributes.HP": "unknown"}}
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader("Attributes.HP\n1\n")), csvstruct.WithMapping(mapping))
+
+	var got Prefab
+	if err := reader.Read(&got); err == nil {
+		t.Errorf("Read() err = %v; want error", err)
+	}
+}
+
+func TestLoadMapping_UnknownField(t *testing.T) {
+	if _, err := csvstruct.LoadMapping(strings.NewReader(`{"renames": {}}`)); err == nil {
+		t.Errorf("LoadMapping() err = %v; want error", err)
+	}
+}
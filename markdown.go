@@ -0,0 +1,90 @@
+package csvstruct
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// markdownCellReplacer escapes the characters that would break a cell of a
+// Markdown table.
+var markdownCellReplacer = strings.NewReplacer("\\", "\\\\", "|", "\\|", "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+// MarkdownWriter writes component data as a GitHub Flavored Markdown table,
+// e.g., to embed data snapshots in design documents and pull request
+// descriptions. The header row contains the qualified names of the columns,
+// like the CSV header written by Writer.
+//
+// This is thread compatible, i.e., it's safe for non-concurrent use and it can
+// be combined with external synchronization so it can be called concurrently.
+type MarkdownWriter[T any] struct {
+	encoder[T]
+	// Underlying writer.
+	writer *bufio.Writer
+	// Whether the header has been written.
+	hasHeader bool
+}
+
+// writeRow writes a row of cells, escaping them.
+func (w *MarkdownWriter[T]) writeRow(cells []string) error {
+	w.writer.WriteString("|")
+	for _, cell := range cells {
+		w.writer.WriteString(" ")
+		markdownCellReplacer.WriteString(w.writer, cell)
+		w.writer.WriteString(" |")
+	}
+	_, err := w.writer.WriteString("\n")
+	return err
+}
+
+// Write writes `t` as a row of the table. Before the first row, the header row
+// and the delimiter row are written.
+//
+// Writes are buffered, so Flush must be called to ensure that the table is
+// written to the underlying io.Writer.
+func (w *MarkdownWriter[T]) Write(t T) error {
+	if w.err != nil {
+		return w.err
+	}
+	if !w.hasHeader {
+		if err := w.writeRow(w.header()); err != nil {
+			return err
+		}
+
+		w.writer.WriteString("|")
+		for range w.columns {
+			w.writer.WriteString(" --- |")
+		}
+		if _, err := w.writer.WriteString("\n"); err != nil {
+			return err
+		}
+
+		w.hasHeader = true
+	}
+
+	record, err := w.encode(t)
+	if err != nil {
+		return err
+	}
+	return w.writeRow(record)
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *MarkdownWriter[T]) Flush() error {
+	return w.writer.Flush()
+}
+
+// NewMarkdownWriter returns a new writer that writes a Markdown table to
+// `writer`. The type `T` is the schema that is used to write the data.
+//
+// The writer can be configured with the same options as Writer, e.g.,
+// WithWriteComponents.
+//
+// If the type `T` is not a struct or if the options select components or
+// fields that `T` doesn't have, the first Write returns the error.
+func NewMarkdownWriter[T any](writer io.Writer, opts ...WriterOption) *MarkdownWriter[T] {
+	return &MarkdownWriter[T]{
+		encoder: newEncoder[T](opts),
+		writer:  bufio.NewWriter(writer),
+	}
+}
@@ -0,0 +1,30 @@
+package csvstruct_test
+
+import (
+	"os"
+
+	"github.com/jabolopes/csvstruct"
+)
+
+func ExampleMarkdownWriter() {
+	prefabs := append(testPrefabs, Prefab{Info: &Info{"Jordan | Jo", "Rogue\nThief"}})
+
+	writer := csvstruct.NewMarkdownWriter[Prefab](os.Stdout)
+	for _, prefab := range prefabs {
+		if err := writer.Write(prefab); err != nil {
+			panic(err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// | Info.Name | Info.Class | Attributes.HP | Attributes.Damage | Player |
+	// | --- | --- | --- | --- | --- |
+	// | Alex | Fighter | 100 | 10 |  |
+	// | Jayden | Wizard | 90 | 20 |  |
+	// | Mary | Queen |  |  |  |
+	// | Player |  |  |  | 1 |
+	// | Jordan \| Jo | Rogue<br>Thief |  |  |  |
+}
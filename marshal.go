@@ -0,0 +1,43 @@
+package csvstruct
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// Type of the encoding.TextMarshaler interface.
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	// Type of the encoding.TextUnmarshaler interface.
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// textCodec is the codec of types that implement encoding.TextUnmarshaler,
+// e.g., net.IP or custom enums, which don't have a registered codec.
+type textCodec struct{}
+
+// isTextType returns whether values of `typ` are decoded with textCodec.
+// time.Time fields are parsed with a layout instead, which can be set per
+// field.
+func isTextType(typ reflect.Type) bool {
+	return typ != timeType && reflect.PointerTo(typ).Implements(textUnmarshalerType)
+}
+
+func (textCodec) Decode(cell string, dst reflect.Value) error {
+	return dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(cell))
+}
+
+func (textCodec) Encode(src reflect.Value) (string, error) {
+	if !src.Type().Implements(textMarshalerType) && src.CanAddr() {
+		src = src.Addr()
+	}
+
+	marshaler, ok := src.Interface().(encoding.TextMarshaler)
+	if !ok {
+		return "", fmt.Errorf("type %s doesn't implement encoding.TextMarshaler", src.Type())
+	}
+
+	text, err := marshaler.MarshalText()
+	return string(text), err
+}
@@ -0,0 +1,153 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Element int
+
+const (
+	Fire Element = iota + 1
+	Water
+)
+
+func (e Element) MarshalText() ([]byte, error) {
+	switch e {
+	case Fire:
+		return []byte("fire"), nil
+	case Water:
+		return []byte("water"), nil
+	}
+	return nil, fmt.Errorf("invalid element %d", int(e))
+}
+
+func (e *Element) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "fire":
+		*e = Fire
+	case "water":
+		*e = Water
+	default:
+		return fmt.Errorf("invalid element %q", text)
+	}
+	return nil
+}
+
+type Server struct {
+	Address net.IP
+	Element Element
+}
+
+type Shard struct {
+	Server *Server
+}
+
+func TestTextUnmarshaler(t *testing.T) {
+	const data = `Server.Address,Server.Element
+10.0.0.1,fire
+::1,
+`
+
+	want := []Shard{
+		{&Server{net.ParseIP("10.0.0.1"), Fire}},
+		{&Server{net.ParseIP("::1"), 0}},
+	}
+
+	reader := csvstruct.NewReader[Shard](csv.NewReader(strings.NewReader(data)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("ReadAll() diff = %v", diff)
+	}
+
+	want[1].Server.Element = Water
+
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Shard](csv.NewWriter(&buf))
+	for _, shard := range want {
+		if err := writer.Write(shard); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	writer.Flush()
+
+	const wantData = `Server.Address,Server.Element
+10.0.0.1,fire
+::1,water
+`
+	if diff := cmp.Diff(wantData, buf.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+}
+
+func TestTextUnmarshaler_Error(t *testing.T) {
+	const data = `Server.Address,Server.Element
+10.0.0.1,earth
+`
+
+	reader := csvstruct.NewReader[Shard](csv.NewReader(strings.NewReader(data)))
+
+	var got Shard
+	want := `line 2, column 2 (Server.Element): invalid element "earth"`
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
+
+// Sigil implements encoding.TextMarshaler with a pointer receiver.
+type Sigil string
+
+func (s *Sigil) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(*s))), nil
+}
+
+func (s *Sigil) UnmarshalText(text []byte) error {
+	*s = Sigil(strings.ToLower(string(text)))
+	return nil
+}
+
+type Crest struct {
+	Sigil Sigil
+}
+
+type House struct {
+	Crest Crest
+}
+
+func TestWriter_PointerTextMarshalerInValueComponent(t *testing.T) {
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[House](csv.NewWriter(&buf))
+	if err := writer.Write(House{Crest{"wolf"}}); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	const want = `Crest.Sigil
+WOLF
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+
+	reader := csvstruct.NewReader[House](csv.NewReader(strings.NewReader(buf.String())))
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff([]House{{Crest{"wolf"}}}, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
@@ -0,0 +1,98 @@
+package csvstruct
+
+import (
+	"errors"
+	"strings"
+)
+
+// parseMetadataRow parses a metadata row, e.g., '# version: 3', and returns
+// the key, the value, and true, or false if the row is not a metadata row.
+// Metadata rows start with '#'. A metadata row without ':' is a comment, whose
+// key is empty.
+//
+// Since the underlying CSV reader splits the row at separators, the cells are
+// joined again with `comma`.
+func parseMetadataRow(row []string, comma rune) (string, string, bool) {
+	if len(row) == 0 || !strings.HasPrefix(row[0], "#") {
+		return "", "", false
+	}
+
+	line := strings.TrimPrefix(strings.Join(row, string(comma)), "#")
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return "", "", true
+	}
+	return strings.TrimSpace(key), strings.TrimSpace(value), true
+}
+
+// readHeaderRow reads the row that should be a CSV header. With the
+// WithMetadata option, the metadata rows before it are parsed into the
+// metadata of this reader.
+func (r *Reader[T]) readHeaderRow() ([]string, error) {
+	if !r.options.metadata {
+		return r.reader.Read()
+	}
+
+	// Metadata rows have a different number of fields than the CSV header.
+	fieldsPerRecord := r.reader.FieldsPerRecord
+	r.reader.FieldsPerRecord = -1
+	defer func() {
+		r.reader.FieldsPerRecord = fieldsPerRecord
+	}()
+
+	for {
+		row, err := r.reader.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		key, value, ok := parseMetadataRow(row, r.reader.Comma)
+		if !ok {
+			if fieldsPerRecord == 0 {
+				// Like the underlying CSV reader, the CSV header determines the
+				// number of fields of the data rows.
+				fieldsPerRecord = len(row)
+			}
+			return row, nil
+		}
+
+		if len(key) > 0 {
+			if r.metadata == nil {
+				r.metadata = map[string]string{}
+			}
+			r.metadata[key] = value
+		}
+	}
+}
+
+// Metadata returns the metadata read so far, indexed by key, e.g., version,
+// author, or export date. Only used with the WithMetadata option.
+func (r *Reader[T]) Metadata() map[string]string {
+	return r.metadata
+}
+
+// WithMetadata enables metadata rows, which track provenance, e.g., version,
+// author, or export date, inside the CSV data.
+//
+// Metadata rows are rows before a CSV header whose first cell starts with '#',
+// e.g., '# version: 3', as written by Writer.WriteMetadata. They are parsed
+// into key and value pairs that are returned by Reader.Metadata. Metadata rows
+// without ':' are comments, which are skipped.
+//
+// The Comment field of the underlying CSV reader must not be '#', otherwise
+// metadata rows are skipped by the CSV reader.
+func WithMetadata() Option {
+	return func(o *options) {
+		o.metadata = true
+	}
+}
+
+// WriteMetadata writes a metadata row, e.g., '# version: 3', which is parsed
+// by readers created with the WithMetadata option. It must be called before
+// the first row of a table.
+func (w *Writer[T]) WriteMetadata(key, value string) error {
+	if w.hasHeader {
+		return errors.New("metadata must be written before the first row of a table")
+	}
+	return w.writer.Write([]string{"# " + key + ": " + value})
+}
@@ -0,0 +1,79 @@
+package csvstruct_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderMetadata(t *testing.T) {
+	const data = `# version: 3
+# author: Smith, J
+# a comment
+Info.Name,Info.Class
+Alex,Fighter
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithMetadata())
+
+	var got Prefab
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(Prefab{Info: &Info{"Alex", "Fighter"}}, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+
+	want := map[string]string{"version": "3", "author": "Smith, J"}
+	if diff := cmp.Diff(want, reader.Metadata()); diff != "" {
+		t.Errorf("Metadata() diff = %v", diff)
+	}
+}
+
+func TestReaderMetadata_FieldCount(t *testing.T) {
+	const data = `# version: 3
+Info.Name,Info.Class
+Alex
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithMetadata())
+
+	var got Prefab
+	if err := reader.Read(&got); err == nil {
+		t.Errorf("Read() err = %v; want error", err)
+	}
+}
+
+func TestWriterMetadata(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := csvstruct.NewWriter[Prefab](csv.NewWriter(&buffer), csvstruct.WithWriteComponents("Info"))
+	if err := writer.WriteMetadata("author", "Smith, J"); err != nil {
+		t.Fatalf("WriteMetadata() err = %v; want %v", err, nil)
+	}
+	if err := writer.Write(testPrefabs[0]); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.WriteMetadata("version", "3"); err == nil {
+		t.Errorf("WriteMetadata() err = %v; want error", err)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(&buffer), csvstruct.WithMetadata())
+
+	var got Prefab
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := map[string]string{"author": "Smith, J"}
+	if diff := cmp.Diff(want, reader.Metadata()); diff != "" {
+		t.Errorf("Metadata() diff = %v", diff)
+	}
+}
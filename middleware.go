@@ -0,0 +1,45 @@
+package csvstruct
+
+import "errors"
+
+// ErrSkipRow is returned by a Middleware to skip a row, e.g., to filter rows.
+// Read then continues with the next row.
+var ErrSkipRow = errors.New("skip row")
+
+// Row is a data row that is being decoded by a Reader.
+type Row struct {
+	// Line of the row in the CSV data.
+	Line int
+	// CSV header of the current table. It must not be modified.
+	Header []string
+	// Cells of the row, which are decoded by the core decoding step. Middleware
+	// can rewrite them before calling the next RowFunc. The slice is reused by
+	// the next row, so it must be copied to be kept.
+	Cells []string
+	// Pointer to the value of type `T` into which the row is decoded. It's
+	// populated when the core decoding step returns.
+	Value any
+}
+
+// RowFunc decodes a row. The innermost RowFunc is the core decoding step,
+// which decodes the cells of the row into its value.
+type RowFunc func(row *Row) error
+
+// Middleware wraps a RowFunc with a cross-cutting behavior, e.g., logging,
+// metrics, filtering, rewriting, or validation. A middleware calls `next` to
+// continue decoding, and it can inspect or modify the row before and after
+// that call. It can also skip the row by returning ErrSkipRow, or stop reading
+// by returning another error.
+type Middleware func(next RowFunc) RowFunc
+
+// WithMiddleware wraps the decoding of each data row with the given
+// middleware. The first middleware is the outermost, i.e., it's called first.
+// This option can be given multiple times, which appends the middleware.
+//
+// Read returns the errors of middleware, other than ErrSkipRow, like decoding
+// errors. Skipped rows are reported to observers with EventRowSkipped.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
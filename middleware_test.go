@@ -0,0 +1,95 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderMiddleware(t *testing.T) {
+	var log []string
+	logging := func(next csvstruct.RowFunc) csvstruct.RowFunc {
+		return func(row *csvstruct.Row) error {
+			err := next(row)
+			log = append(log, fmt.Sprintf("line %d: %v", row.Line, err))
+			return err
+		}
+	}
+
+	filter := func(next csvstruct.RowFunc) csvstruct.RowFunc {
+		return func(row *csvstruct.Row) error {
+			if row.Cells[0] == "Mary" {
+				return csvstruct.ErrSkipRow
+			}
+			return next(row)
+		}
+	}
+
+	rewrite := func(next csvstruct.RowFunc) csvstruct.RowFunc {
+		return func(row *csvstruct.Row) error {
+			row.Cells[1] = strings.ToUpper(row.Cells[1])
+			if err := next(row); err != nil {
+				return err
+			}
+			row.Value.(*Prefab).Info.Name += "!"
+			return nil
+		}
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)), csvstruct.WithMiddleware(logging, filter), csvstruct.WithMiddleware(rewrite))
+
+	want := []Prefab{
+		{&Info{"Alex!", "FIGHTER"}, &Attributes{100, 10}, nil},
+		{&Info{"Jayden!", "WIZARD"}, &Attributes{90, 20}, nil},
+		{&Info{"Player!", ""}, nil, &Player{}},
+	}
+
+	for _, want := range want {
+		var got Prefab
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+
+	var got Prefab
+	if err := reader.Read(&got); err != io.EOF {
+		t.Fatalf("Read() err = %v; want %v", err, io.EOF)
+	}
+
+	wantLog := []string{"line 2: <nil>", "line 3: <nil>", "line 4: skip row", "line 5: <nil>"}
+	if diff := cmp.Diff(wantLog, log); diff != "" {
+		t.Errorf("log diff = %v", diff)
+	}
+}
+
+func TestReaderMiddleware_Error(t *testing.T) {
+	errInvalid := errors.New("invalid row")
+	validate := func(next csvstruct.RowFunc) csvstruct.RowFunc {
+		return func(row *csvstruct.Row) error {
+			if row.Line == 3 {
+				return errInvalid
+			}
+			return next(row)
+		}
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)), csvstruct.WithMiddleware(validate))
+
+	var got Prefab
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+	if err := reader.Read(&got); !errors.Is(err, errInvalid) {
+		t.Fatalf("Read() err = %v; want %v", err, errInvalid)
+	}
+}
@@ -0,0 +1,180 @@
+package csvstruct
+
+import (
+	"encoding/csv"
+	"fmt"
+	"slices"
+)
+
+// tableReader is a Reader of any type, which reads a table of a MultiReader.
+type tableReader interface {
+	parseHeader(row []string) error
+	readAny() (any, error)
+	Section() string
+}
+
+// readAny reads the next row, like Read, and returns it as a value of `T`.
+func (r *Reader[T]) readAny() (any, error) {
+	var t T
+	if err := r.Read(&t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// tableType is a type registered with RegisterTable.
+type tableType struct {
+	// Name of the section of the tables of this type, or empty if tables are
+	// matched by their CSV header.
+	section string
+	// Schema of the type.
+	schema Schema
+	// Creates a Reader of the type that reads from the given CSV reader.
+	newReader func(*csv.Reader) tableReader
+}
+
+// matchesHeader returns whether all the columns of `header` are in the schema
+// of the type.
+func (t *tableType) matchesHeader(header []string) bool {
+	for _, column := range header {
+		if !slices.Contains(t.schema.Columns, column) {
+			return false
+		}
+	}
+	return true
+}
+
+// MultiReader reads CSV data that contains tables of different types, e.g., a
+// spreadsheet export with items, enemies, and loot tables in separate
+// sections. The types are registered with RegisterTable, and the type of each
+// table is chosen either by the section row before it, e.g., '[Enemies]', as
+// written by Writer.WriteSection, or by its CSV header.
+//
+// This is thread compatible, i.e., it's safe for non-concurrent use and it can
+// be combined with external synchronization so it can be called concurrently.
+type MultiReader struct {
+	// Underlying CSV reader.
+	reader *csv.Reader
+	// Types registered with RegisterTable, in order.
+	types []tableType
+	// Reader of the current table, or nil if the next row is a section row or
+	// a CSV header.
+	current tableReader
+	// Name of the current section, from the most recent section row.
+	section string
+	// Permanent error. If there is one, it's returned on all Read calls.
+	permanentErr error
+}
+
+// RegisterTable registers the type `T` for the tables of `m` in the section
+// `section`, i.e., the tables after a '[section]' row. If `section` is empty,
+// the type is registered for the tables that are not in a registered section,
+// and whose CSV header columns are all in the schema of `T`, in the order in
+// which the types are registered.
+//
+// The tables of `T` are read by a Reader[T] configured with the given options.
+func RegisterTable[T any](m *MultiReader, section string, opts ...Option) {
+	opts = append(slices.Clip(opts), WithSections())
+	m.types = append(m.types, tableType{
+		section: section,
+		schema:  SchemaFor[T](),
+		newReader: func(reader *csv.Reader) tableReader {
+			return NewReader[T](reader, opts...)
+		},
+	})
+}
+
+// findType returns the type of the table in the current section whose CSV
+// header is `header`, or nil if there is none.
+func (m *MultiReader) findType(header []string) *tableType {
+	if len(m.section) > 0 {
+		for i := range m.types {
+			if m.types[i].section == m.section {
+				return &m.types[i]
+			}
+		}
+	}
+
+	for i := range m.types {
+		if len(m.types[i].section) == 0 && m.types[i].matchesHeader(header) {
+			return &m.types[i]
+		}
+	}
+	return nil
+}
+
+// startTable reads the section rows and the CSV header of the next table, and
+// creates the reader of the table.
+func (m *MultiReader) startTable() error {
+	for {
+		row, err := m.reader.Read()
+		if err != nil {
+			return err
+		}
+
+		if name, ok := parseSectionRow(row); ok {
+			m.section = name
+			continue
+		}
+
+		typ := m.findType(row)
+		if typ == nil {
+			return fmt.Errorf("no type is registered for section %q or CSV header %q", m.section, row)
+		}
+
+		reader := typ.newReader(m.reader)
+		if err := reader.parseHeader(row); err != nil {
+			return err
+		}
+		m.current = reader
+		return nil
+	}
+}
+
+// Read reads the next row of the CSV data, and returns it as a value of the
+// type registered for its table, e.g., a Prefab rather than a *Prefab.
+// Tables end at section rows, which start the next table.
+//
+// Returns io.EOF at the end of the CSV data. Like Reader.Read, this either
+// returns a row or an error, but never both simultaneously.
+func (m *MultiReader) Read() (any, error) {
+	for m.permanentErr == nil {
+		if m.current == nil {
+			if err := m.startTable(); err != nil {
+				m.permanentErr = err
+				break
+			}
+		}
+
+		row, err := m.current.readAny()
+		if err == ErrEndOfSection {
+			m.section = m.current.Section()
+			m.current = nil
+			continue
+		}
+		if err != nil {
+			m.permanentErr = err
+			break
+		}
+		return row, nil
+	}
+	return nil, m.permanentErr
+}
+
+// Section returns the name of the section of the most recently read row, or
+// empty if it's not in a section.
+func (m *MultiReader) Section() string {
+	return m.section
+}
+
+// NewMultiReader returns a new reader of tables of different types using the
+// given `reader` as the underlying CSV reader. The types of the tables must be
+// registered with RegisterTable before the first call to Read.
+//
+// Since tables can have different numbers of columns, this sets the
+// FieldsPerRecord of the underlying CSV reader to -1.
+func NewMultiReader(reader *csv.Reader) *MultiReader {
+	reader.ReuseRecord = true
+	reader.FieldsPerRecord = -1
+	return &MultiReader{reader: reader}
+}
@@ -0,0 +1,71 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestMultiReader(t *testing.T) {
+	const data = `[Heroes]
+Info.Name,Attributes.HP
+Alex,100
+[Loot]
+Drop.Enemy,Drop.Item,Drop.Chance
+Goblin,Dagger,0.5
+[Misc]
+Info.Name,Stats.HP
+Zoe,70
+`
+
+	reader := csvstruct.NewMultiReader(csv.NewReader(strings.NewReader(data)))
+	csvstruct.RegisterTable[Prefab](reader, "Heroes")
+	csvstruct.RegisterTable[Loot](reader, "Loot")
+	// Matched by the CSV header, because it's not in a registered section.
+	csvstruct.RegisterTable[Unit](reader, "")
+
+	type row struct {
+		Section string
+		Value   any
+	}
+
+	var got []row
+	for {
+		value, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+		got = append(got, row{reader.Section(), value})
+	}
+
+	want := []row{
+		{"Heroes", Prefab{Info: &Info{Name: "Alex"}, Attributes: &Attributes{HP: 100}}},
+		{"Loot", Loot{&Drop{"Goblin", "Dagger", 0.5}}},
+		{"Misc", Unit{&Info{Name: "Zoe"}, &Stats{70}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+}
+
+func TestMultiReader_UnknownTable(t *testing.T) {
+	const data = `[Quests]
+Quest.Name
+Rescue
+`
+
+	reader := csvstruct.NewMultiReader(csv.NewReader(strings.NewReader(data)))
+	csvstruct.RegisterTable[Prefab](reader, "Heroes")
+
+	want := `no type is registered for section "Quests" or CSV header ["Quest.Name"]`
+	if _, err := reader.Read(); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
@@ -0,0 +1,158 @@
+package csvstruct
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Fields are configured with two struct tags, which are parsed by tagOptions:
+//
+//   - The `csv` tag configures how the field is laid out in the table. It
+//     starts with a column pattern, e.g., 'Stat_*', or is empty, and its
+//     options are 'key', 'zero', 'nil', and 'unknown', e.g., `csv:",key"`.
+//
+//   - The `csvstruct` tag configures the column of the field. It starts with
+//     the column name, which can be omitted, and its options are 'required',
+//     'default=', 'enum=', and 'layout=', e.g., `csvstruct:"hp,default=100"`.
+
+// tagOptions returns the options of `tag`, i.e., the comma-separated elements
+// after the name, e.g., 'key' for the `csv` tag ",key". The first element is
+// also an option if `isOption` reports so, e.g., `csvstruct:"required"`, or
+// the name otherwise, including if `isOption` is nil.
+func tagOptions(tag string, isOption func(string) bool) []string {
+	if len(tag) == 0 {
+		return nil
+	}
+
+	opts := strings.Split(tag, ",")
+	if isOption == nil || !isOption(opts[0]) {
+		opts = opts[1:]
+	}
+	return opts
+}
+
+// hasTagOption returns whether the `csv` tag of `field` has the given option
+// after the name, e.g., 'key' in `csv:",key"`.
+func hasTagOption(field reflect.StructField, option string) bool {
+	return slices.Contains(tagOptions(field.Tag.Get("csv"), nil), option)
+}
+
+// columnOptionKeywords are the options of the `csvstruct` tag without values,
+// which can also start the tag, e.g., `csvstruct:"required"`, and therefore
+// can't be column names.
+var columnOptionKeywords = []string{"required"}
+
+// isColumnOption returns whether the first element `s` of a `csvstruct` tag is
+// an option rather than a column name, e.g., 'required' or 'default=100'.
+func isColumnOption(s string) bool {
+	return strings.Contains(s, "=") || slices.Contains(columnOptionKeywords, s)
+}
+
+// parseTag parses the `csvstruct` tag of `field`, e.g.,
+// `csvstruct:"date,layout=2006-01-02"`, into the column name and the time
+// layout, either of which can be empty. The layout is the last option, since
+// it can contain commas, e.g., 'Jan 2, 2006'.
+func parseTag(field reflect.StructField) (string, string) {
+	tag := field.Tag.Get("csvstruct")
+
+	var layout string
+	if strings.HasPrefix(tag, "layout=") {
+		tag, layout = "", strings.TrimPrefix(tag, "layout=")
+	} else if before, after, ok := strings.Cut(tag, ",layout="); ok {
+		tag, layout = before, after
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if isColumnOption(name) {
+		// The tag starts with an option, e.g., `csvstruct:"required"`.
+		name = ""
+	}
+	return name, layout
+}
+
+// columnOptions returns the options of the `csvstruct` tag of `field` that
+// follow the column name, except the layout, e.g., 'required' and
+// 'default=100' for `csvstruct:"hp,required,default=100"`. Options can also
+// start the tag, e.g., `csvstruct:"required"` or `csvstruct:"default=100"`.
+func columnOptions(field reflect.StructField) []string {
+	tag := field.Tag.Get("csvstruct")
+	if strings.HasPrefix(tag, "layout=") {
+		return nil
+	}
+	tag, _, _ = strings.Cut(tag, ",layout=")
+	return tagOptions(tag, isColumnOption)
+}
+
+// hasColumnOption returns whether the `csvstruct` tag of `field` has the given
+// option, e.g., 'required' for `csvstruct:"required"`, `csvstruct:",required"`,
+// or `csvstruct:"hp,required"`.
+func hasColumnOption(field reflect.StructField, option string) bool {
+	return slices.Contains(columnOptions(field), option)
+}
+
+// columnOptionValue returns the value of the option `key` of the `csvstruct`
+// tag of `field`, e.g., '100' for the key 'default' and the tag
+// `csvstruct:",default=100"`, and whether the option is present.
+func columnOptionValue(field reflect.StructField, key string) (string, bool) {
+	for _, opt := range columnOptions(field) {
+		if value, ok := strings.CutPrefix(opt, key+"="); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// columnName returns the name of a component or a field in CSV headers, which
+// is the name in its `csvstruct` tag, if any, e.g., 'display_name' for
+// `csvstruct:"display_name"`, or its Go name otherwise.
+func columnName(field reflect.StructField) string {
+	if name, _ := parseTag(field); len(name) > 0 {
+		return name
+	}
+	return field.Name
+}
+
+// fieldByColumnName returns the exported field of the struct type `typ` whose
+// column name is `name`. Fields with a name in their `csvstruct` tag are only
+// found by that name, not by their Go name.
+func fieldByColumnName(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if tagName, _ := parseTag(field); field.IsExported() && len(tagName) > 0 && tagName == name {
+			return field, true
+		}
+	}
+
+	field, ok := typ.FieldByName(name)
+	if !ok || !field.IsExported() || columnName(field) != name {
+		return reflect.StructField{}, false
+	}
+	return field, true
+}
+
+// nestedFieldByColumnName returns the exported field of the struct type `typ`
+// whose column path is `path`, e.g., 'Offense.Damage' for the field 'Damage'
+// of the struct field 'Offense' of `typ`, walking structs and pointers to
+// structs. The index of the returned field is relative to `typ`, for
+// reflect.Value.FieldByIndex.
+func nestedFieldByColumnName(typ reflect.Type, path string) (reflect.StructField, bool) {
+	var index []int
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		field, ok := fieldByColumnName(typ, name)
+		if !ok {
+			return reflect.StructField{}, false
+		}
+		index = append(index, field.Index...)
+
+		if i == len(names)-1 {
+			field.Index = index
+			return field, true
+		}
+		if typ, ok = componentStruct(field.Type); !ok {
+			return reflect.StructField{}, false
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,130 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Profile struct {
+	DisplayName string `csvstruct:"display_name"`
+	Bio         string
+}
+
+type Account struct {
+	Profile *Profile `csvstruct:"info"`
+}
+
+const accountData = `info.display_name,info.Bio
+Alex,Fighter
+`
+
+func TestReaderColumnNames(t *testing.T) {
+	reader := csvstruct.NewReader[Account](csv.NewReader(strings.NewReader(accountData)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Account{{&Profile{"Alex", "Fighter"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestReaderColumnNames_GoName(t *testing.T) {
+	// Tagged fields are only found by their tag.
+	const data = `Profile.DisplayName
+Alex
+`
+
+	reader := csvstruct.NewReader[Account](csv.NewReader(strings.NewReader(data)))
+
+	var got Account
+	if err := reader.Read(&got); err == nil {
+		t.Errorf("Read() err = %v; want error", err)
+	}
+}
+
+func TestWriterColumnNames(t *testing.T) {
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Account](csv.NewWriter(&buf))
+	if err := writer.Write(Account{&Profile{"Alex", "Fighter"}}); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(accountData, buf.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+
+	want := csvstruct.Schema{Columns: []string{"info.display_name", "info.Bio"}}
+	if diff := cmp.Diff(want, csvstruct.SchemaFor[Account]()); diff != "" {
+		t.Errorf("SchemaFor() diff = %v", diff)
+	}
+}
+
+type Offense struct {
+	Damage int
+	Crit   float64 `csvstruct:"crit_chance"`
+}
+
+type Combat struct {
+	Offense Offense
+	Defense *struct {
+		Armor int
+	}
+	Speed int
+}
+
+type Fighter struct {
+	Combat *Combat
+}
+
+func TestReaderNestedNames(t *testing.T) {
+	const data = `Combat.Offense.Damage,Combat.Offense.crit_chance,Combat.Defense.Armor,Combat.Speed
+12,0.25,5,3
+7,,,
+`
+
+	reader := csvstruct.NewReader[Fighter](csv.NewReader(strings.NewReader(data)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Fighter{
+		{&Combat{Offense: Offense{Damage: 12, Crit: 0.25}, Defense: &struct{ Armor int }{5}, Speed: 3}},
+		{&Combat{Offense: Offense{Damage: 7}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestReaderNestedNames_Errors(t *testing.T) {
+	reader := csvstruct.NewReader[Fighter](csv.NewReader(strings.NewReader("Combat.Offense.Power\n")))
+
+	var got Fighter
+	if err := reader.Read(&got); err == nil || !strings.Contains(err.Error(), `does not have a field "Offense.Power"`) {
+		t.Errorf("Read() err = %v; want missing field error", err)
+	}
+
+	reader = csvstruct.NewReader[Fighter](csv.NewReader(strings.NewReader("Combat.Offense.Damage\nhigh\n")))
+
+	var parseErr *csvstruct.ParseError
+	if err := reader.Read(&got); !errors.As(err, &parseErr) {
+		t.Fatalf("Read() err = %v; want %T", err, parseErr)
+	}
+	if want := "Combat.Offense.Damage"; parseErr.Name != want || parseErr.Field != want {
+		t.Errorf("ParseError = %q, %q; want %q, %q", parseErr.Name, parseErr.Field, want, want)
+	}
+}
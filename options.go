@@ -0,0 +1,299 @@
+package csvstruct
+
+import (
+	"io"
+	"io/fs"
+	"reflect"
+	"time"
+)
+
+// options holds the configuration of a Reader.
+type options struct {
+	// Filesystem used to validate asset references. If nil, asset references
+	// are not validated.
+	assetFS fs.FS
+	// String table used to validate and resolve localization keys. If nil,
+	// localization keys are not validated.
+	stringTable StringTable
+	// Whether '[name]' rows start new sections.
+	sections bool
+	// Whether metadata rows are parsed before CSV headers.
+	metadata bool
+	// Whether CSV headers are followed by units rows.
+	units bool
+	// Whether unnamed trailing columns are ignored.
+	trailingEmptyColumns bool
+	// Whether a hash of each decoded row is computed.
+	rowHash bool
+	// Whether the decoding of each data row is timed, i.e., the WithRowTiming
+	// option was given.
+	rowTiming bool
+	// Threshold given with WithRowTiming.
+	slowRowThreshold time.Duration
+	// Number of structs per slab given with WithSlabAllocation, or 0 if
+	// components are allocated one at a time.
+	slabSize int
+	// Whether header columns that are not in `T` are ignored.
+	ignoreUnknownColumns bool
+	// Layout of time.Time fields given with WithTimeLayout.
+	timeLayout string
+	// Schema given with WithSchema, or nil if there is none.
+	schema *Schema
+	// Names of the components given with WithDefaultComponents.
+	defaultComponents []string
+	// Cells of bool fields given with WithBoolValues. If both are nil, the
+	// default values are used.
+	trueValues  []string
+	falseValues []string
+	// Handling of cells that are not valid UTF-8.
+	utf8Policy UTF8Policy
+	// Codecs given with WithCodec, indexed by type.
+	codecs map[reflect.Type]Codec
+	// Concrete types of union fields given with WithUnion, indexed by the
+	// qualified name of the field and then by type name.
+	unions map[string]map[string]reflect.Type
+	// Mapping given with WithMapping.
+	mapping Mapping
+	// Observers given with WithObserver.
+	observers []Observer
+	// Middleware given with WithMiddleware, outermost first.
+	middleware []Middleware
+	// Source of the CSV data given with WithRowTimeout. If nil, rows are read
+	// without deadlines.
+	deadlineSource ReadDeadliner
+	// Timeout given with WithRowTimeout.
+	rowTimeout time.Duration
+	// Rate limiters given with WithRowRateLimit and WithByteRateLimit, or nil
+	// if reading is not rate limited.
+	rowLimiter  RateLimiter
+	byteLimiter RateLimiter
+	// Policy given with WithColumnAccess, or nil if all the columns are
+	// allowed.
+	allowColumn func(string) bool
+	// Handling of the columns that are not allowed by `allowColumn`.
+	accessMode AccessMode
+	// Whether empty cells of number fields are decoded as zeros, i.e., the
+	// WithEmptyNumbersAsZero option was given.
+	emptyNumbersAsZero bool
+	// Whether components must have all or none of their cells non-empty, i.e.,
+	// the WithCompleteComponents option was given.
+	completeComponents bool
+	// Normalizer given with WithHeaderNormalizer, or nil if CSV header columns
+	// are matched as they are.
+	headerNormalizer func(string) string
+	// Whether CSV header columns are matched ignoring case, i.e., the
+	// WithCaseInsensitiveHeaders option was given.
+	caseInsensitiveHeaders bool
+	// Whether cells are audited, i.e., the WithAudit option was given.
+	audit bool
+	// Separator of the elements of list cells given with WithListSeparator, or
+	// empty for the default separator.
+	listSeparator string
+	// Separators of the pairs and of the keys and values of map cells given
+	// with WithMapSeparators, or empty for the default separators.
+	pairSeparator string
+	keySeparator  string
+	// Whether tables are separated by blank rows, i.e., the
+	// WithBlankRowSeparators option was given.
+	blankRowSeparators bool
+	// Handler given with WithSkipInvalidRows, or nil if invalid rows stop
+	// reading.
+	invalidRowHandler func(error)
+	// Source of the CSV data given with WithQuoteTracking. If nil, quoting is
+	// not tracked.
+	quoteSource io.ReaderAt
+	// Source of the CSV data given with WithQuoteRecovery. If nil, records with
+	// malformed quotes are not recovered.
+	recoverySource io.ReaderAt
+}
+
+// Option configures a Reader. Options are passed to NewReader.
+type Option func(*options)
+
+// WithAssetFS validates asset references against the given filesystem.
+//
+// Component fields tagged with `asset:"dir"` contain paths to assets, e.g.,
+// textures or sounds. When this option is given, the path in each non-empty
+// cell of such a field is joined with 'dir' and it must exist in `fsys`,
+// otherwise Read returns an error that includes the row and column of the
+// cell. The tag value can be empty, in which case the cell contains the full
+// path in `fsys`.
+func WithAssetFS(fsys fs.FS) Option {
+	return func(o *options) {
+		o.assetFS = fsys
+	}
+}
+
+// StringTable contains localized strings indexed by localization key.
+type StringTable interface {
+	// Lookup returns the localized string for the given key, and whether the key
+	// exists.
+	Lookup(key string) (string, bool)
+}
+
+// WithStringTable validates localization keys against the given string table.
+//
+// Component fields tagged with `loc:"key"` contain localization keys. When this
+// option is given, each non-empty cell of such a field must be a key that
+// exists in `table`, otherwise Read returns an error that includes the row and
+// column of the cell. The field keeps the localization key.
+//
+// Component fields tagged with `loc:"text"` also contain localization keys,
+// which are validated in the same way, but the field is populated with the
+// localized string instead of the key. Without this option, these fields keep
+// the localization key.
+func WithStringTable(table StringTable) Option {
+	return func(o *options) {
+		o.stringTable = table
+	}
+}
+
+// WithCodec uses the given codec to decode fields of the given type. It takes
+// precedence over codecs registered with RegisterCodec.
+func WithCodec(typ reflect.Type, codec Codec) Option {
+	return func(o *options) {
+		if o.codecs == nil {
+			o.codecs = map[reflect.Type]Codec{}
+		}
+		o.codecs[typ] = codec
+	}
+}
+
+// WithUnion gives the concrete types of a union field, indexed by type name.
+//
+// A union field is a component field of interface type, e.g., `any`, tagged
+// with `union:"MyTypeField"`, where 'MyTypeField' is a sibling field of the same
+// component whose cells contain type names, e.g., the field 'Effect.Params'
+// tagged with `union:"Type"` whose type is chosen by the field 'Effect.Type'.
+// The name `qualName` is the qualified name of the union field, e.g.,
+// 'Effect.Params'.
+//
+// A non-empty cell of a union field is decoded into the type named by the
+// sibling cell of the same row, using a codec if one is registered for that
+// type, or otherwise parsing numbers and strings directly and other types,
+// e.g., structs, as JSON.
+func WithUnion(qualName string, types map[string]reflect.Type) Option {
+	return func(o *options) {
+		if o.unions == nil {
+			o.unions = map[string]map[string]reflect.Type{}
+		}
+		o.unions[qualName] = types
+	}
+}
+
+// WithMapping renames CSV header columns and decodes fields with named codecs
+// as given by `mapping`, e.g., loaded with LoadMapping.
+func WithMapping(mapping Mapping) Option {
+	return func(o *options) {
+		o.mapping = mapping
+	}
+}
+
+// WithSections enables sections, which allow the same CSV data to contain
+// multiple tables, e.g., as written by Writer.WriteSection.
+//
+// A section row contains the section name within square brackets in the first
+// cell, e.g., '[Enemies]', and the remaining cells, if any, are empty. A section
+// row before the first CSV header is skipped. A section row after a table ends
+// that table and Read returns ErrEndOfSection. The caller can then use
+// Reader.Clear to continue reading the next table, whose first row is a CSV
+// header, or create a new Reader for a different type using the same
+// csv.Reader. Reader.Section returns the name of the most recent section row.
+//
+// Since tables in different sections can have different numbers of columns,
+// this sets the FieldsPerRecord of the underlying CSV reader to -1.
+func WithSections() Option {
+	return func(o *options) {
+		o.sections = true
+	}
+}
+
+// WithIgnoreUnknownColumns ignores the CSV header columns that are not in
+// `T`, e.g., extra columns in shared spreadsheets, instead of returning a
+// HeaderError. Their cells are skipped.
+//
+// Columns that are in `T` but are otherwise invalid are still reported.
+func WithIgnoreUnknownColumns() Option {
+	return func(o *options) {
+		o.ignoreUnknownColumns = true
+	}
+}
+
+// WithTrailingEmptyColumns ignores unnamed columns at the end of CSV headers,
+// e.g., the column created by a dangling comma in 'Info.Name,Info.Class,'.
+//
+// The cells of data rows that are beyond the last named column must be empty,
+// otherwise Read returns an error that includes the row and column of the
+// cell.
+func WithTrailingEmptyColumns() Option {
+	return func(o *options) {
+		o.trailingEmptyColumns = true
+	}
+}
+
+// writerOptions holds the configuration of a Writer.
+type writerOptions struct {
+	// Selected components and fields, indexed by name, e.g., 'MyComponent' or
+	// 'MyComponent.MyField', and whether the selection matched a column. If nil,
+	// all components and fields are written.
+	components map[string]bool
+	// Codecs given with WithWriteCodec, indexed by type.
+	codecs map[reflect.Type]Codec
+	// CSV header given with WithColumnOrder, or nil if the columns are written
+	// in the order of the fields of `T`.
+	columnOrder []string
+	// Whether fields tagged with `redact` are redacted, i.e., the
+	// WithRedaction option was given.
+	redaction bool
+	// Separator of the elements of list cells given with
+	// WithWriteListSeparator, or empty for the default separator.
+	listSeparator string
+	// Separators of the pairs and of the keys and values of map cells given
+	// with WithWriteMapSeparators, or empty for the default separators.
+	pairSeparator string
+	keySeparator  string
+}
+
+// WriterOption configures a Writer. Options are passed to NewWriter.
+type WriterOption func(*writerOptions)
+
+// WithWriteComponents selects the components and fields that are written,
+// e.g., 'MyComponent' selects all the fields of the component and
+// 'MyComponent.MyField' selects a single field. Columns are written in the
+// order of the fields of `T`, regardless of the order of the selection.
+//
+// This allows writing trimmed views of a type without defining another type.
+func WithWriteComponents(names ...string) WriterOption {
+	return func(o *writerOptions) {
+		if o.components == nil {
+			o.components = map[string]bool{}
+		}
+		for _, name := range names {
+			o.components[name] = false
+		}
+	}
+}
+
+// WithRedaction redacts the fields tagged with `redact`, i.e., it writes the
+// tag's value instead of the field's value, e.g., `redact:"***"` writes '***'
+// and `redact:""` writes an empty cell, so that exports for external partners
+// can be written from the same types. Nil components are still written as
+// empty cells.
+//
+// Without this option, the `redact` tag is ignored.
+func WithRedaction() WriterOption {
+	return func(o *writerOptions) {
+		o.redaction = true
+	}
+}
+
+// WithWriteCodec uses the given codec to encode fields of the given type. It
+// takes precedence over codecs registered with RegisterCodec.
+func WithWriteCodec(typ reflect.Type, codec Codec) WriterOption {
+	return func(o *writerOptions) {
+		if o.codecs == nil {
+			o.codecs = map[reflect.Type]Codec{}
+		}
+		o.codecs[typ] = codec
+	}
+}
@@ -0,0 +1,191 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Sprite struct {
+	Name    string
+	Texture string `asset:"textures"`
+}
+
+type Actor struct {
+	Sprite *Sprite
+}
+
+var assetFS = fstest.MapFS{
+	"textures/hero.png": &fstest.MapFile{},
+}
+
+func TestWithAssetFS(t *testing.T) {
+	const data = `Sprite.Name,Sprite.Texture
+Hero,hero.png
+Nobody,
+`
+
+	want := []Actor{
+		{&Sprite{"Hero", "hero.png"}},
+		{&Sprite{"Nobody", ""}},
+	}
+
+	reader := csvstruct.NewReader[Actor](csv.NewReader(strings.NewReader(data)), csvstruct.WithAssetFS(assetFS))
+
+	for _, want := range want {
+		var got Actor
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+}
+
+func TestWithAssetFS_Missing(t *testing.T) {
+	const data = `Sprite.Name,Sprite.Texture
+Hero,hero.png
+Villain,villain.png
+`
+
+	reader := csvstruct.NewReader[Actor](csv.NewReader(strings.NewReader(data)), csvstruct.WithAssetFS(assetFS))
+
+	var got Actor
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	err := reader.Read(&got)
+	if err == nil || !strings.Contains(err.Error(), "line 3, column 2 (Sprite.Texture)") {
+		t.Fatalf("Read() err = %v; want error with location", err)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Read() err = %v; want %v", err, fs.ErrNotExist)
+	}
+}
+
+type stringTable map[string]string
+
+func (t stringTable) Lookup(key string) (string, bool) {
+	text, ok := t[key]
+	return text, ok
+}
+
+type Dialog struct {
+	Title string `loc:"key"`
+	Body  string `loc:"text"`
+}
+
+type Quest struct {
+	Dialog *Dialog
+}
+
+func TestWithStringTable(t *testing.T) {
+	const data = `Dialog.Title,Dialog.Body
+quest.title,quest.body
+`
+
+	table := stringTable{
+		"quest.title": "The Lost Sword",
+		"quest.body":  "Find the sword.",
+	}
+
+	want := Quest{&Dialog{"quest.title", "Find the sword."}}
+
+	reader := csvstruct.NewReader[Quest](csv.NewReader(strings.NewReader(data)), csvstruct.WithStringTable(table))
+
+	var got Quest
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Read() diff = %v", diff)
+	}
+}
+
+func TestWithStringTable_Missing(t *testing.T) {
+	const data = `Dialog.Title,Dialog.Body
+quest.title,quest.missing
+`
+
+	table := stringTable{"quest.title": "The Lost Sword"}
+
+	reader := csvstruct.NewReader[Quest](csv.NewReader(strings.NewReader(data)), csvstruct.WithStringTable(table))
+
+	var got Quest
+	err := reader.Read(&got)
+	if err == nil || !strings.Contains(err.Error(), `line 2, column 2 (Dialog.Body): localization key "quest.missing" not found`) {
+		t.Fatalf("Read() err = %v; want missing localization key error", err)
+	}
+}
+
+func TestWithTrailingEmptyColumns(t *testing.T) {
+	const data = `Info.Name,Info.Class,,
+Alex,Fighter,,
+Jayden,Wizard,,
+`
+
+	want := []Prefab{
+		{Info: &Info{"Alex", "Fighter"}},
+		{Info: &Info{"Jayden", "Wizard"}},
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithTrailingEmptyColumns())
+
+	for _, want := range want {
+		var got Prefab
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+}
+
+func TestWithTrailingEmptyColumns_NonEmpty(t *testing.T) {
+	const data = `Info.Name,Info.Class,
+Alex,Fighter,
+Jayden,Wizard,oops
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithTrailingEmptyColumns())
+
+	var got Prefab
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	const want = `line 3, column 3: unnamed trailing column has non-empty cell "oops"`
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
+
+func TestWithIgnoreUnknownColumns(t *testing.T) {
+	const data = `Info.Name,Notes,Info.Class,Info.Level
+Alex,tank,Fighter,3
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithIgnoreUnknownColumns())
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Prefab{{Info: &Info{"Alex", "Fighter"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
@@ -0,0 +1,156 @@
+package csvstruct
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const (
+	// defaultPairSeparator is the separator of the pairs of map cells, unless
+	// another one is given with WithMapSeparators or WithWriteMapSeparators.
+	defaultPairSeparator = ";"
+	// defaultKeySeparator is the separator of the key and the value of the
+	// pairs of map cells, unless another one is given with WithMapSeparators or
+	// WithWriteMapSeparators.
+	defaultKeySeparator = "="
+)
+
+// mapCodec is the codec of map fields, e.g., map[string]int, whose key-value
+// pairs are stored in a single cell, e.g., 'fire=3;ice=1'.
+type mapCodec struct {
+	// Codecs of the keys and the values, or nil if they are decoded as numbers,
+	// bools, or strings.
+	key   Codec
+	value Codec
+	// Codecs of the reader or writer, used to parse the keys and the values.
+	codecs map[reflect.Type]Codec
+	// Parser of bool keys and values, or nil for the default true and false
+	// values.
+	parseBool func(string) (bool, error)
+	// Separator of the pairs.
+	pairSeparator string
+	// Separator of the key and the value of each pair.
+	keySeparator string
+}
+
+// newMapCodec returns the codec of the map type `typ`, whose pairs are
+// separated by `pairSeparator`, whose keys and values are separated by
+// `keySeparator`, and whose bool keys and values are parsed with `parseBool`,
+// or nil if `typ` is not a map or if its keys or values can't be stored in
+// cells, i.e., they are neither numbers, bools, strings, nor types with
+// codecs.
+func newMapCodec(codecs map[reflect.Type]Codec, parseBool func(string) (bool, error), typ reflect.Type, pairSeparator, keySeparator string) Codec {
+	if typ.Kind() != reflect.Map {
+		return nil
+	}
+
+	codec := mapCodec{
+		codecs:        codecs,
+		parseBool:     parseBool,
+		pairSeparator: cmp.Or(pairSeparator, defaultPairSeparator),
+		keySeparator:  cmp.Or(keySeparator, defaultKeySeparator),
+	}
+	codec.key = lookupCodec(codecs, typ.Key())
+	codec.value = lookupCodec(codecs, typ.Elem())
+	if codec.key == nil && (typ.Key() == timeType || !isWritableField(typ.Key())) {
+		return nil
+	}
+	if codec.value == nil && (typ.Elem() == timeType || !isWritableField(typ.Elem())) {
+		return nil
+	}
+	return codec
+}
+
+// Decode splits `cell` into pairs and each pair into its key and value, and
+// parses them after removing their leading and trailing spaces, e.g.,
+// 'fire=3; ice=1' decodes as {"fire": 3, "ice": 1}.
+func (c mapCodec) Decode(cell string, dst reflect.Value) error {
+	m := reflect.MakeMap(dst.Type())
+	for _, pair := range strings.Split(cell, c.pairSeparator) {
+		k, v, ok := strings.Cut(pair, c.keySeparator)
+		if !ok {
+			return fmt.Errorf("pair %q of %q has no %q; want key%svalue", pair, cell, c.keySeparator, c.keySeparator)
+		}
+
+		key, err := parseDefault(c.codecs, c.parseBool, dst.Type().Key(), strings.TrimSpace(k))
+		if err != nil {
+			return fmt.Errorf("key of pair %q of %q: %w", pair, cell, err)
+		}
+		if m.MapIndex(key).IsValid() {
+			return fmt.Errorf("duplicate key %q in %q", strings.TrimSpace(k), cell)
+		}
+
+		value, err := parseDefault(c.codecs, c.parseBool, dst.Type().Elem(), strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("value of pair %q of %q: %w", pair, cell, err)
+		}
+		m.SetMapIndex(key, value)
+	}
+	dst.Set(m)
+	return nil
+}
+
+// encodeElem formats `src` with `codec`, or as a number, bool, or string if
+// `codec` is nil.
+func encodeElem(codec Codec, src reflect.Value) (string, error) {
+	if codec == nil {
+		return formatCell(src), nil
+	}
+	return codec.Encode(src)
+}
+
+// Encode formats the pairs of `src`, sorted by key, and joins them with the
+// separators. Nil and empty maps are encoded as empty cells.
+//
+// Returns an error if a key or a value contains either separator or has
+// leading or trailing spaces, since it would decode differently.
+func (c mapCodec) Encode(src reflect.Value) (string, error) {
+	pairs := make([]string, 0, src.Len())
+	iter := src.MapRange()
+	for iter.Next() {
+		key, err := encodeElem(c.key, iter.Key())
+		if err == nil {
+			err = checkEncodedElem(key, c.pairSeparator, c.keySeparator)
+		}
+		if err != nil {
+			return "", fmt.Errorf("key: %w", err)
+		}
+		value, err := encodeElem(c.value, iter.Value())
+		if err == nil {
+			err = checkEncodedElem(value, c.pairSeparator, c.keySeparator)
+		}
+		if err != nil {
+			return "", fmt.Errorf("value of key %q: %w", key, err)
+		}
+		pairs = append(pairs, key+c.keySeparator+value)
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, c.pairSeparator), nil
+}
+
+// WithMapSeparators sets the separators of the cells of map fields, e.g.,
+// map[string]int, i.e., the separator of the pairs, which is ';' by default,
+// and the separator of the key and the value of each pair, which is '=' by
+// default, e.g., the cell 'fire=3;ice=1' decodes as {"fire": 3, "ice": 1}.
+// Empty cells decode as nil maps.
+//
+// Map fields whose `csv` tag is a pattern, e.g., `csv:"Stat_*"`, are decoded
+// from several columns instead.
+func WithMapSeparators(pairSeparator, keySeparator string) Option {
+	return func(o *options) {
+		o.pairSeparator = pairSeparator
+		o.keySeparator = keySeparator
+	}
+}
+
+// WithWriteMapSeparators sets the separators of the cells of map fields,
+// which are ';' and '=' by default. See WithMapSeparators.
+func WithWriteMapSeparators(pairSeparator, keySeparator string) WriterOption {
+	return func(o *writerOptions) {
+		o.pairSeparator = pairSeparator
+		o.keySeparator = keySeparator
+	}
+}
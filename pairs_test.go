@@ -0,0 +1,124 @@
+package csvstruct_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Resistance struct {
+	Elements map[string]int
+	Tags     map[string]string
+}
+
+type Elemental struct {
+	Resistance *Resistance
+}
+
+func TestReaderMap(t *testing.T) {
+	const data = `Resistance.Elements,Resistance.Tags
+fire=3; ice=1,biome=tundra
+,
+`
+
+	reader := csvstruct.NewReader[Elemental](csv.NewReader(strings.NewReader(data)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Elemental{
+		{&Resistance{Elements: map[string]int{"fire": 3, "ice": 1}, Tags: map[string]string{"biome": "tundra"}}},
+		{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestReaderMap_Invalid(t *testing.T) {
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{"fire", `pair "fire" of "fire" has no "="`},
+		{"fire=hot", `value of pair "fire=hot" of "fire=hot"`},
+		{"fire=1;fire=2", `duplicate key "fire" in "fire=1;fire=2"`},
+	}
+
+	for _, test := range tests {
+		data := "Resistance.Elements\n" + test.cell + "\n"
+		reader := csvstruct.NewReader[Elemental](csv.NewReader(strings.NewReader(data)))
+
+		var got Elemental
+		if err := reader.Read(&got); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Read() err = %v; want %v", err, test.want)
+		}
+	}
+}
+
+func TestWithMapSeparators(t *testing.T) {
+	const data = `Resistance.Elements
+fire:3|ice:1
+`
+
+	reader := csvstruct.NewReader[Elemental](csv.NewReader(strings.NewReader(data)), csvstruct.WithMapSeparators("|", ":"))
+
+	var got Elemental
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Elemental{&Resistance{Elements: map[string]int{"fire": 3, "ice": 1}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+}
+
+func TestWriter_Map(t *testing.T) {
+	rows := []Elemental{
+		{&Resistance{Elements: map[string]int{"ice": 1, "fire": 3}, Tags: map[string]string{"biome": "tundra"}}},
+	}
+
+	var buffer bytes.Buffer
+	writer := csvstruct.NewWriter[Elemental](csv.NewWriter(&buffer), csvstruct.WithWriteMapSeparators("|", ":"))
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	const want = `Resistance.Elements,Resistance.Tags
+fire:3|ice:1,biome:tundra
+`
+	if got := buffer.String(); got != want {
+		t.Errorf("Write() = %q; want %q", got, want)
+	}
+
+	reader := csvstruct.NewReader[Elemental](csv.NewReader(&buffer), csvstruct.WithMapSeparators("|", ":"))
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(rows, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestWriter_MapAmbiguousPairs(t *testing.T) {
+	for _, tags := range []map[string]string{{"k": "x=y;z"}, {"k": "x;y"}, {"k=v": "x"}, {"k": " x"}} {
+		writer := csvstruct.NewWriter[Elemental](csv.NewWriter(io.Discard))
+		if err := writer.Write(Elemental{&Resistance{Tags: tags}}); err == nil {
+			t.Errorf("Write(%q) err = %v; want error", tags, err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package csvstruct
+
+import (
+	"reflect"
+	"slices"
+)
+
+// unknownFieldIndex returns the index of the field of `typ` that captures the
+// unknown columns, i.e., a field of type map[string]string tagged with
+// `csv:",unknown"`, or -1 if there is none.
+func unknownFieldIndex(typ reflect.Type) int {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.IsExported() && field.Type == reflect.TypeFor[map[string]string]() && hasTagOption(field, "unknown") {
+			return i
+		}
+	}
+	return -1
+}
+
+// UnknownCells returns the cells of the ignored columns of the most recently
+// read row, i.e., the columns that are not in `T` with the
+// WithIgnoreUnknownColumns or WithSchema options, or if `T` has a field tagged
+// with `csv:",unknown"`, indexed by column name as in the CSV header, or nil if
+// there are none.
+//
+// The field of `T` of type map[string]string tagged with `csv:",unknown"`, if
+// any, captures the same cells, e.g., to keep them together with each row.
+//
+// Together with Reader.Header, this allows rewriting a file with
+// Writer.WriteWithUnknown without dropping the columns that `T` doesn't know
+// about.
+func (r *Reader[T]) UnknownCells() map[string]string {
+	return r.unknownCells
+}
+
+// orderColumns reorders the columns in the order of `header`. The columns of
+// `header` that are not written from `T` become presence or unknown columns,
+// as given by headerColumn, and the columns of `T` that are not in `header` are
+// written after them, in the order of the fields of `T`.
+func (e *encoder[T]) orderColumns(header []string) {
+	columns := make([]writeColumn, 0, len(header)+len(e.columns))
+	for _, name := range header {
+		i := slices.IndexFunc(e.columns, func(column writeColumn) bool {
+			return column.qualName == name
+		})
+		if i < 0 {
+			columns = append(columns, headerColumn[T](name))
+			continue
+		}
+		columns = append(columns, e.columns[i])
+	}
+
+	for _, column := range e.columns {
+		if !slices.Contains(header, column.qualName) {
+			columns = append(columns, column)
+		}
+	}
+
+	e.columns = columns
+}
+
+// headerColumn returns the column `name` of a header given with
+// WithColumnOrder that is not written from a field of `T`. If `name` is the
+// name of a component, e.g., 'MyComponent', it's a presence column of that
+// component. Otherwise, it's an unknown column.
+func headerColumn[T any](name string) writeColumn {
+	if field, ok := fieldByColumnName(reflect.TypeFor[T](), name); ok && len(field.Index) == 1 {
+		if _, ok := componentStruct(field.Type); ok {
+			return writeColumn{qualName: name, componentIndex: field.Index[0], fieldIndex: -1}
+		}
+	}
+	return writeColumn{qualName: name, componentIndex: -1, fieldIndex: -1}
+}
+
+// WithColumnOrder writes the columns in the order of `header`, e.g., the CSV
+// header of the file being rewritten as returned by Reader.Header, so that
+// automated edits don't reorganize files that are maintained by hand.
+//
+// The columns of `header` that are the names of components with fields, e.g.,
+// 'MyComponent', are presence columns, which are written like marker
+// components, i.e., '1' if the component is present and empty otherwise. The
+// other columns of `header` that are not written from `T` are unknown columns,
+// whose cells are written from the field of `T` tagged with `csv:",unknown"`,
+// if any, or given to Writer.WriteWithUnknown, e.g., as returned by
+// Reader.UnknownCells, and are empty otherwise. The columns of `T` that are not
+// in `header` are written after the columns of `header`.
+func WithColumnOrder(header []string) WriterOption {
+	return func(o *writerOptions) {
+		o.columnOrder = slices.Clone(header)
+	}
+}
+
+// WriteWithUnknown writes `t` as a CSV row, like Write, and fills the cells of
+// the unknown columns given with WithColumnOrder from `cells`, indexed by
+// column name, e.g., as returned by Reader.UnknownCells. The cells in `cells`
+// take precedence over the field of `T` tagged with `csv:",unknown"`.
+func (w *Writer[T]) WriteWithUnknown(t T, cells map[string]string) error {
+	if err := w.WriteHeader(); err != nil {
+		return err
+	}
+
+	record, err := w.encode(t)
+	if err != nil {
+		return err
+	}
+
+	for i, column := range w.columns {
+		if cell, ok := cells[column.qualName]; ok && column.isUnknown() {
+			record[i] = cell
+		}
+	}
+	return w.writer.Write(record)
+}
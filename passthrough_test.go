@@ -0,0 +1,168 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderUnknownCells(t *testing.T) {
+	const data = `Info.Name,Notes,Attributes.HP,Owner
+Alex,tank,100,mary
+Jayden,,90,
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithIgnoreUnknownColumns())
+
+	want := []map[string]string{
+		{"Notes": "tank", "Owner": "mary"},
+		{"Notes": "", "Owner": ""},
+	}
+
+	for _, want := range want {
+		var got Prefab
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, reader.UnknownCells()); diff != "" {
+			t.Errorf("UnknownCells() diff = %v", diff)
+		}
+	}
+
+	wantHeader := []string{"Info.Name", "Notes", "Attributes.HP", "Owner"}
+	if diff := cmp.Diff(wantHeader, reader.Header()); diff != "" {
+		t.Errorf("Header() diff = %v", diff)
+	}
+}
+
+func TestWriterColumnOrder(t *testing.T) {
+	const data = `Attributes.HP,Notes,Info.Name
+100,tank,Alex
+90,,Jayden
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithIgnoreUnknownColumns())
+
+	var buf strings.Builder
+	var writer *csvstruct.Writer[Prefab]
+	for {
+		var prefab Prefab
+		err := reader.Read(&prefab)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if writer == nil {
+			writer = csvstruct.NewWriter[Prefab](csv.NewWriter(&buf), csvstruct.WithColumnOrder(reader.Header()), csvstruct.WithWriteComponents("Info.Name", "Attributes.HP"))
+		}
+
+		prefab.Attributes.HP++
+		if err := writer.WriteWithUnknown(prefab, reader.UnknownCells()); err != nil {
+			t.Fatalf("WriteWithUnknown() err = %v; want %v", err, nil)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want := `Attributes.HP,Notes,Info.Name
+101,tank,Alex
+91,,Jayden
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteWithUnknown() diff = %v", diff)
+	}
+}
+
+func TestWriterColumnOrder_NewColumns(t *testing.T) {
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Prefab](csv.NewWriter(&buf), csvstruct.WithColumnOrder([]string{"Info.Class", "Info.Name"}))
+
+	want := []string{"Info.Class", "Info.Name", "Attributes.HP", "Attributes.Damage", "Player"}
+	if diff := cmp.Diff(want, writer.Header()); diff != "" {
+		t.Errorf("Header() diff = %v", diff)
+	}
+}
+
+type Entry struct {
+	Info       *Info
+	Attributes *Attributes
+	Unknown    map[string]string `csv:",unknown"`
+}
+
+func TestUnknownField(t *testing.T) {
+	const data = `Notes,Info.Name,Attributes.HP,Owner
+tank,Alex,100,mary
+,Jayden,90,
+`
+
+	reader := csvstruct.NewReader[Entry](csv.NewReader(strings.NewReader(data)))
+
+	entries, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Entry{
+		{&Info{Name: "Alex"}, &Attributes{HP: 100}, map[string]string{"Notes": "tank", "Owner": "mary"}},
+		{&Info{Name: "Jayden"}, &Attributes{HP: 90}, map[string]string{"Notes": "", "Owner": ""}},
+	}
+	if diff := cmp.Diff(want, entries); diff != "" {
+		t.Fatalf("ReadAll() diff = %v", diff)
+	}
+
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Entry](csv.NewWriter(&buf), csvstruct.WithColumnOrder([]string{"Notes", "Info.Name", "Attributes.HP", "Owner"}), csvstruct.WithWriteComponents("Info.Name", "Attributes.HP"))
+	for _, entry := range entries {
+		entry.Unknown["Owner"] = "zoe"
+		if err := writer.Write(entry); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	wantData := `Notes,Info.Name,Attributes.HP,Owner
+tank,Alex,100,zoe
+,Jayden,90,zoe
+`
+	if diff := cmp.Diff(wantData, buf.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+}
+
+func TestWriter_PresenceColumn(t *testing.T) {
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Prefab](csv.NewWriter(&buf), csvstruct.WithColumnOrder([]string{"Info.Name", "Attributes", "Attributes.HP"}))
+
+	for _, prefab := range []Prefab{
+		{Info: &Info{Name: "Alex"}, Attributes: &Attributes{}},
+		{Info: &Info{Name: "Mary"}},
+	} {
+		if err := writer.Write(prefab); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want := `Info.Name,Attributes,Attributes.HP,Info.Class,Attributes.Damage,Player
+Alex,1,0,,0,
+Mary,,,,,
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+}
@@ -0,0 +1,59 @@
+package csvstruct
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// matchPattern matches `name` against `pattern`, which contains a single
+// wildcard '*', e.g., 'Stat_*', and returns the part of `name` matched by the
+// wildcard, e.g., 'Str' for 'Stat_Str'.
+func matchPattern(pattern, name string) (string, bool) {
+	prefix, suffix, ok := strings.Cut(pattern, "*")
+	if !ok || len(name) < len(prefix)+len(suffix) || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return "", false
+	}
+	return name[len(prefix) : len(name)-len(suffix)], true
+}
+
+// isPatternField returns whether the `csv` tag name of `field` is a pattern,
+// e.g., 'Stat_*', i.e., whether the field captures several columns.
+func isPatternField(field reflect.StructField) bool {
+	pattern, _, _ := strings.Cut(field.Tag.Get("csv"), ",")
+	return strings.Contains(pattern, "*")
+}
+
+// findPatternField finds the field of `componentType` whose `csv` tag name is a
+// pattern that matches the header column field name `fieldName`, and returns
+// that field and the part of `fieldName` matched by the wildcard.
+//
+// Fields with patterns must be maps with string keys, e.g., `map[string]int`.
+func findPatternField(componentType reflect.Type, fieldName string) (reflect.StructField, string, bool, error) {
+	for i := 0; i < componentType.NumField(); i++ {
+		field := componentType.Field(i)
+		tag, ok := field.Tag.Lookup("csv")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		// The tag can also have options, e.g., 'key', after the pattern.
+		pattern, _, _ := strings.Cut(tag, ",")
+		if len(pattern) == 0 {
+			continue
+		}
+
+		if strings.Count(pattern, "*") != 1 {
+			return reflect.StructField{}, "", false, fmt.Errorf("field %q of type %s has invalid csv tag %q; want a pattern with a single '*', e.g., 'MyField_*'", field.Name, componentType.String(), pattern)
+		}
+		if field.Type.Kind() != reflect.Map || field.Type.Key().Kind() != reflect.String {
+			return reflect.StructField{}, "", false, fmt.Errorf("field %q of type %s has a csv tag but it's not a map with string keys", field.Name, componentType.String())
+		}
+
+		if key, ok := matchPattern(pattern, fieldName); ok {
+			return field, key, true, nil
+		}
+	}
+
+	return reflect.StructField{}, "", false, nil
+}
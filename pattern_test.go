@@ -0,0 +1,72 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Traits struct {
+	Level  int
+	Stats  map[string]int     `csv:"Stat_*"`
+	Resist map[string]float64 `csv:"*Resist"`
+}
+
+type Hero struct {
+	Info   *Info
+	Traits *Traits
+}
+
+func TestReaderPattern(t *testing.T) {
+	const data = `Info.Name,Traits.Level,Traits.Stat_Str,Traits.Stat_Dex,Traits.FireResist,Traits.Stat_Int
+Knight,3,12,8,0.5,
+Mage,5,,,,15
+`
+
+	want := []Hero{
+		{&Info{Name: "Knight"}, &Traits{3, map[string]int{"Str": 12, "Dex": 8}, map[string]float64{"Fire": 0.5}}},
+		{&Info{Name: "Mage"}, &Traits{5, map[string]int{"Int": 15}, nil}},
+	}
+
+	reader := csvstruct.NewReader[Hero](csv.NewReader(strings.NewReader(data)))
+
+	for _, want := range want {
+		var got Hero
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+}
+
+func TestReaderPattern_UnknownColumn(t *testing.T) {
+	reader := csvstruct.NewReader[Hero](csv.NewReader(strings.NewReader("Traits.Luck\n1\n")))
+
+	var got Hero
+	if err := reader.Read(&got); err == nil {
+		t.Errorf("Read() err = %v; want error", err)
+	}
+}
+
+type BadTraits struct {
+	Stats []int `csv:"Stat_*"`
+}
+
+type BadHero struct {
+	Traits *BadTraits
+}
+
+func TestReaderPattern_NotMap(t *testing.T) {
+	reader := csvstruct.NewReader[BadHero](csv.NewReader(strings.NewReader("Traits.Stat_Str\n1\n")))
+
+	var got BadHero
+	if err := reader.Read(&got); err == nil || !strings.Contains(err.Error(), "not a map") {
+		t.Errorf("Read() err = %v; want error containing %q", err, "not a map")
+	}
+}
@@ -0,0 +1,32 @@
+package csvstruct
+
+import (
+	"reflect"
+	"strings"
+)
+
+// findProtoField finds the field of `componentType` whose proto name or JSON
+// name matches `name`, e.g., 'display_name' or 'displayName', as given by the
+// `protobuf` tags of protobuf-generated message types, e.g.:
+//
+//	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3"`
+//
+// In proto3 messages, the JSON name is omitted if it's the same as the proto
+// name.
+func findProtoField(componentType reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < componentType.NumField(); i++ {
+		field := componentType.Field(i)
+		tag, ok := field.Tag.Lookup("protobuf")
+		if !ok || !field.IsExported() {
+			continue
+		}
+
+		for _, option := range strings.Split(tag, ",") {
+			if option == "name="+name || option == "json="+name {
+				return field, true
+			}
+		}
+	}
+
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,71 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jabolopes/csvstruct"
+)
+
+// ItemProto mimics a protobuf-generated message type.
+type ItemProto struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	DisplayName string  `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Price       int32   `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`
+	DropRate    float64 `protobuf:"fixed64,3,opt,name=drop_rate,json=dropRate,proto3" json:"drop_rate,omitempty"`
+}
+
+type ShopItem struct {
+	Item *ItemProto
+}
+
+func TestReaderProto(t *testing.T) {
+	const data = `Item.display_name,Item.price,Item.dropRate
+Sword,100,0.25
+Shield,,
+`
+
+	want := []ShopItem{
+		{&ItemProto{DisplayName: "Sword", Price: 100, DropRate: 0.25}},
+		{&ItemProto{DisplayName: "Shield"}},
+	}
+
+	reader := csvstruct.NewReader[ShopItem](csv.NewReader(strings.NewReader(data)))
+
+	for _, want := range want {
+		var got ShopItem
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(ItemProto{})); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+}
+
+// OptionalProto mimics a protobuf-generated message type with proto3 optional
+// fields.
+type OptionalProto struct {
+	Nickname *string `protobuf:"bytes,1,opt,name=nickname,proto3,oneof" json:"nickname,omitempty"`
+}
+
+func TestReaderProto_UnsupportedField(t *testing.T) {
+	type Profile struct {
+		Optional *OptionalProto
+	}
+
+	reader := csvstruct.NewReader[Profile](csv.NewReader(strings.NewReader("Optional.nickname\nBob\n")))
+
+	var got Profile
+	const want = `column 1 (Optional.nickname): field "nickname" of type *csvstruct_test.OptionalProto has unsupported type *string`
+	if err := reader.Read(&got); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
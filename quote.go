@@ -0,0 +1,207 @@
+package csvstruct
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// quotedFields returns whether each field of the raw CSV record `data` is
+// quoted. The record must be valid, i.e., it must have been parsed by
+// encoding/csv with the same separator.
+func quotedFields(data []byte, comma rune, trimLeadingSpace bool) []bool {
+	sep := []byte(string(comma))
+
+	var quoted []bool
+	for {
+		if trimLeadingSpace {
+			data = bytes.TrimLeft(data, " \t")
+		}
+
+		if !bytes.HasPrefix(data, []byte(`"`)) {
+			quoted = append(quoted, false)
+			i := bytes.Index(data, sep)
+			if j := bytes.IndexAny(data, "\r\n"); i < 0 || j >= 0 && j < i {
+				return quoted
+			}
+			data = data[i+len(sep):]
+			continue
+		}
+
+		quoted = append(quoted, true)
+		i := 1
+		for i < len(data) {
+			if data[i] == '"' {
+				if i+1 < len(data) && data[i+1] == '"' {
+					i += 2
+					continue
+				}
+				break
+			}
+			i++
+		}
+		data = data[min(i+1, len(data)):]
+		if !bytes.HasPrefix(data, sep) {
+			return quoted
+		}
+		data = data[len(sep):]
+	}
+}
+
+// skipIgnoredLines returns `data` without the leading lines that encoding/csv
+// skips, i.e., empty lines and comment lines.
+func skipIgnoredLines(data []byte, comment rune) []byte {
+	for len(data) > 0 {
+		line, rest, _ := bytes.Cut(data, []byte("\n"))
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if len(line) > 0 && (comment == 0 || !bytes.HasPrefix(line, []byte(string(comment)))) {
+			return data
+		}
+		data = rest
+	}
+	return data
+}
+
+// trackQuotes records which cells of the CSV record that started at offset
+// `start` and was just read by the underlying CSV reader were quoted, if the
+// WithQuoteTracking option was given.
+func (r *Reader[T]) trackQuotes(start int64) error {
+	r.quoted = r.quoted[:0]
+	if r.options.quoteSource == nil {
+		return nil
+	}
+
+	data := make([]byte, r.reader.InputOffset()-start)
+	if n, err := r.options.quoteSource.ReadAt(data, r.baseOffset+start); n < len(data) {
+		return fmt.Errorf("failed to track quotes: %v", err)
+	}
+
+	r.quoted = quotedFields(skipIgnoredLines(data, r.reader.Comment), r.reader.Comma, r.reader.TrimLeadingSpace)
+	return nil
+}
+
+// QuotedColumns returns the qualified names, e.g., 'MyComponent.MyField', of the
+// columns whose cells were quoted in the CSV data of the most recently read
+// row. Only used with the WithQuoteTracking option.
+//
+// This can be passed to QuotingWriter.Write to preserve the quoting of the
+// cells when the row is written again.
+func (r *Reader[T]) QuotedColumns() map[string]bool {
+	columns := map[string]bool{}
+	for columnNum, quoted := range r.quoted {
+		if quoted && columnNum < len(r.colDescriptors) {
+			columns[r.colDescriptors[columnNum].qualName()] = true
+		}
+	}
+	return columns
+}
+
+// WithQuoteTracking tracks which cells were quoted in the CSV data, which is
+// returned by Reader.QuotedColumns for each row, e.g., to preserve the quoting
+// when the row is written again with a QuotingWriter.
+//
+// Since encoding/csv doesn't report quoting, each record is read again from
+// `src`, which must contain the same CSV data as the underlying CSV reader.
+// Records recovered by WithQuoteRecovery are not tracked.
+func WithQuoteTracking(src io.ReaderAt) Option {
+	return func(o *options) {
+		o.quoteSource = src
+	}
+}
+
+// fieldNeedsQuotes returns whether `field` must be quoted, with the same rules
+// as csv.Writer.
+func fieldNeedsQuotes(field string, comma rune) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, comma) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r)
+}
+
+// QuotingWriter writes component data as CSV data, like Writer, but it can
+// preserve the quoting of the cells of rows read with the WithQuoteTracking
+// option, so that rewriting a file only changes the cells that changed, e.g.,
+// when a tool reads a file maintained by designers, tweaks one row, and writes
+// it again.
+//
+// This is thread compatible, i.e., it's safe for non-concurrent use and it can
+// be combined with external synchronization so it can be called concurrently.
+type QuotingWriter[T any] struct {
+	encoder[T]
+	// Field delimiter, which is ',' by default, like in csv.Writer.
+	Comma rune
+	// Underlying writer.
+	writer *bufio.Writer
+	// Whether the CSV header has been written.
+	hasHeader bool
+}
+
+// writeRecord writes a CSV record, quoting the cells whose column is in
+// `quoted` and the cells that need quotes.
+func (w *QuotingWriter[T]) writeRecord(record []string, quoted map[string]bool) error {
+	for i, cell := range record {
+		if i > 0 {
+			w.writer.WriteRune(w.Comma)
+		}
+
+		if !quoted[w.columns[i].qualName] && !fieldNeedsQuotes(cell, w.Comma) {
+			w.writer.WriteString(cell)
+			continue
+		}
+
+		w.writer.WriteByte('"')
+		w.writer.WriteString(strings.ReplaceAll(cell, `"`, `""`))
+		w.writer.WriteByte('"')
+	}
+	_, err := w.writer.WriteString("\n")
+	return err
+}
+
+// Write writes `t` as a CSV row, like Writer.Write. The cells of the columns in
+// `quoted`, e.g., as returned by Reader.QuotedColumns, are quoted even if they
+// don't need quotes. Other cells are only quoted if they need quotes.
+//
+// Writes are buffered, so Flush must be called to ensure that the data is
+// written to the underlying io.Writer.
+func (w *QuotingWriter[T]) Write(t T, quoted map[string]bool) error {
+	if w.err != nil {
+		return w.err
+	}
+	if !w.hasHeader {
+		if err := w.writeRecord(w.header(), nil); err != nil {
+			return err
+		}
+		w.hasHeader = true
+	}
+
+	record, err := w.encode(t)
+	if err != nil {
+		return err
+	}
+	return w.writeRecord(record, quoted)
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *QuotingWriter[T]) Flush() error {
+	return w.writer.Flush()
+}
+
+// NewQuotingWriter returns a new writer that writes CSV data to `writer`. The
+// type `T` is the schema that is used to write the data.
+//
+// The writer can be configured with options, e.g., WithWriteComponents.
+//
+// If the type `T` is not a struct or if the options select components or
+// fields that `T` doesn't have, the first Write returns the error.
+func NewQuotingWriter[T any](writer io.Writer, opts ...WriterOption) *QuotingWriter[T] {
+	return &QuotingWriter[T]{encoder: newEncoder[T](opts), Comma: ',', writer: bufio.NewWriter(writer)}
+}
@@ -0,0 +1,76 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestQuoteTracking(t *testing.T) {
+	const data = `Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player
+"Alex",Fighter,100,"10",
+
+# Comment.
+Jayden,"Wizard, ""the"" Grey",90,20,
+`
+
+	src := strings.NewReader(data)
+	csvReader := csv.NewReader(strings.NewReader(data))
+	csvReader.Comment = '#'
+	reader := csvstruct.NewReader[Prefab](csvReader, csvstruct.WithQuoteTracking(src))
+
+	want := []map[string]bool{
+		{"Info.Name": true, "Attributes.Damage": true},
+		{"Info.Class": true},
+	}
+
+	for _, want := range want {
+		var prefab Prefab
+		if err := reader.Read(&prefab); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, reader.QuotedColumns()); diff != "" {
+			t.Errorf("QuotedColumns() diff = %v", diff)
+		}
+	}
+}
+
+func TestQuotingWriter(t *testing.T) {
+	const data = `Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player
+"Alex",Fighter,100,"10",
+Jayden,"Wizard, the Grey",90,20,
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithQuoteTracking(strings.NewReader(data)))
+
+	var b strings.Builder
+	writer := csvstruct.NewQuotingWriter[Prefab](&b)
+
+	for {
+		var prefab Prefab
+		err := reader.Read(&prefab)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if err := writer.Write(prefab, reader.QuotedColumns()); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(data, b.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+}
@@ -0,0 +1,60 @@
+package csvstruct
+
+import (
+	"context"
+	"fmt"
+)
+
+// RateLimiter limits the rate of events, e.g., rows or bytes read per second.
+// It's implemented by rate.Limiter from golang.org/x/time/rate.
+type RateLimiter interface {
+	// WaitN blocks until `n` events are allowed, or until `ctx` is done.
+	WaitN(ctx context.Context, n int) error
+}
+
+// waitRateLimits waits until the rate limiters given with WithRowRateLimit
+// and WithByteRateLimit allow reading the next row. The bytes that were read
+// since the previous wait, i.e., the bytes of the previous row, are waited for
+// before reading the next one, so that rows are never lost if `ctx` is done
+// while waiting.
+func (r *Reader[T]) waitRateLimits(ctx context.Context) error {
+	if r.options.byteLimiter != nil {
+		offset := r.reader.InputOffset()
+		if n := int(offset - r.limitedOffset); n > 0 {
+			if err := r.options.byteLimiter.WaitN(ctx, n); err != nil {
+				return fmt.Errorf("failed to wait for byte rate limit: %w", err)
+			}
+		}
+		r.limitedOffset = offset
+	}
+
+	if r.options.rowLimiter != nil {
+		if err := r.options.rowLimiter.WaitN(ctx, 1); err != nil {
+			return fmt.Errorf("failed to wait for row rate limit: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// WithRowRateLimit limits the rate at which rows are read with `limiter`,
+// e.g., rate.NewLimiter(1000, 100) for 1000 rows per second, to smooth the
+// load that imports place on downstream systems, e.g., databases.
+//
+// Read waits for the limiter before each row, and Reader.ReadToChan waits with
+// its context, so that it can be cancelled while waiting.
+func WithRowRateLimit(limiter RateLimiter) Option {
+	return func(o *options) {
+		o.rowLimiter = limiter
+	}
+}
+
+// WithByteRateLimit limits the rate at which the CSV data is read with
+// `limiter`, in bytes, like WithRowRateLimit. The bytes of each row are waited
+// for before the next row is read, so the burst of `limiter` must be at least
+// the size of the largest row.
+func WithByteRateLimit(limiter RateLimiter) Option {
+	return func(o *options) {
+		o.byteLimiter = limiter
+	}
+}
@@ -0,0 +1,69 @@
+package csvstruct_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+// countingLimiter records the events that are waited for, and fails when its
+// context is done.
+type countingLimiter struct {
+	waits []int
+}
+
+func (l *countingLimiter) WaitN(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.waits = append(l.waits, n)
+	return nil
+}
+
+func TestReaderRateLimit(t *testing.T) {
+	const data = "Info.Name\nAlex\nMary\n"
+
+	var rows, bytes countingLimiter
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithRowRateLimit(&rows), csvstruct.WithByteRateLimit(&bytes))
+
+	if _, err := reader.ReadAll(); err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	// Each read waits for a row, including the read that returns io.EOF.
+	if diff := cmp.Diff([]int{1, 1, 1}, rows.waits); diff != "" {
+		t.Errorf("WithRowRateLimit() waits diff = %v", diff)
+	}
+
+	// The bytes of the CSV header and the first row are waited for before the
+	// second row, and the bytes of the second row before the end of the data.
+	if diff := cmp.Diff([]int{15, 5}, bytes.waits); diff != "" {
+		t.Errorf("WithByteRateLimit() waits diff = %v", diff)
+	}
+}
+
+// blockingLimiter never allows events.
+type blockingLimiter struct{}
+
+func (blockingLimiter) WaitN(ctx context.Context, n int) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestReaderRateLimit_Cancel(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)), csvstruct.WithRowRateLimit(blockingLimiter{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	ch := make(chan Prefab, len(testPrefabs))
+	if err := reader.ReadToChan(ctx, ch); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ReadToChan() err = %v; want %v", err, context.DeadlineExceeded)
+	}
+}
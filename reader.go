@@ -1,19 +1,26 @@
 package csvstruct
 
 import (
+	"cmp"
+	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"maps"
+	"path"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
-
-	"github.com/mitchellh/mapstructure"
+	"time"
 )
 
 // Parses a qualified name, e.g., 'MyComponent.Myfield', into its parts, e.g.,
 // 'MyComponent' and 'MyField'. It's also valid if the name only contains the
-// component name without a field, e.g., 'MyComponent'.
+// component name without a field, e.g., 'MyComponent'. The field name can be
+// the path of a nested field, e.g., 'MyStruct.MyField'.
 func parseHeaderColumnName(qualName string) (string, string, error) {
 	splits := strings.SplitN(qualName, ".", 2)
 	if len(splits) == 1 {
@@ -33,8 +40,76 @@ func parseHeaderColumnName(qualName string) (string, string, error) {
 
 type colDescriptor struct {
 	kind          reflect.Kind
+	typ           reflect.Type
 	componentName string
 	fieldName     string
+	// Whether the column is ignored because it's not in `T`, e.g., with the
+	// WithIgnoreUnknownColumns or WithSchema options.
+	ignored bool
+	// Whether the column is ignored because it's not allowed by the policy
+	// given with WithColumnAccess.
+	forbidden bool
+	// Index of the component field in `T`, for reflect.Value.FieldByIndex.
+	componentIndex []int
+	// Whether the component field is a slice whose elements are decoded from
+	// repeated column groups, e.g., 'Drops[0].Item' and 'Drops[1].Item', and
+	// the index of the element of the column.
+	isRepeated    bool
+	repeatedIndex int
+	// Whether the field is nested in structs of the component, e.g.,
+	// 'Stats.Offense.Damage', in which case `fieldIndex` is the path from the
+	// component to the field.
+	isNested bool
+	// Index of the field in the component, or of the map field if the column
+	// matches a pattern. It's empty if the column only marks the presence of
+	// the component.
+	fieldIndex []int
+	// Whether the field is tagged as an asset reference.
+	isAsset bool
+	// Directory of the asset reference, from the field's `asset` tag.
+	assetDir string
+	// Localization mode, from the field's `loc` tag. It's either empty, "key",
+	// or "text".
+	locMode string
+	// Whether the field is a reference to another row of the same table, i.e.,
+	// the field has type `*T`.
+	isRef bool
+	// Codec of the field's type, or nil if there is none.
+	codec Codec
+	// Union of the field, or nil if the field is not a union.
+	union *union
+	// Name of the map field that captures this column, if the column matches
+	// the pattern in the map field's `csv` tag, e.g., 'Stats' for the column
+	// 'MyComponent.Stat_Str' and the pattern 'Stat_*'. In that case, `kind` and
+	// `typ` describe the map's values.
+	mapField string
+	// Key of the column in the map field, i.e., the part of the column name
+	// matched by the pattern's wildcard, e.g., 'Str'.
+	mapKey string
+	// Layout of the cells of a time.Time field, or empty if the field is not a
+	// time.Time.
+	timeLayout string
+	// Canonical unit of the field, from the field's `unit` tag.
+	unit string
+	// Factor that converts cells from the unit of the column to the canonical
+	// unit, or 0 if no conversion is needed. Only used with the WithUnits
+	// option.
+	unitScale float64
+	// Whether the cells of the column must be non-empty, i.e., the field is
+	// tagged with `csvstruct:",required"`.
+	required bool
+	// Values that the non-empty cells of the column can have, from the `enum`
+	// option of the field's `csvstruct` tag, e.g., `csvstruct:",enum=a|b"`, or
+	// nil if any value is allowed.
+	enum []string
+}
+
+// qualName returns the qualified name of the column, e.g., 'MyComponent.MyField'.
+func (d *colDescriptor) qualName() string {
+	if len(d.fieldName) == 0 {
+		return d.componentName
+	}
+	return d.componentName + "." + d.fieldName
 }
 
 // Reader parses component data from CSV data.
@@ -50,88 +125,684 @@ type Reader[T any] struct {
 	hasDescriptors bool
 	// Column descriptor.
 	colDescriptors []colDescriptor
+	// CSV header from which the column descriptors were created.
+	header []string
+	// Offset in the CSV data where the underlying CSV reader started reading.
+	// It's non-zero for readers created by ResumeReader.
+	baseOffset int64
+	// Number of lines in the CSV data before the underlying CSV reader started
+	// reading. It's used to report line numbers relative to the start of the
+	// CSV data.
+	baseLine int
+	// Options given to NewReader.
+	options options
+	// References to other rows found in the most recently read row.
+	refs []rowRef
+	// Name of the current section, from the most recent '[name]' row. Only used
+	// with the WithSections option.
+	section string
+	// Line of the most recently read row, if it was recovered by
+	// recoverRecord, or 0 otherwise.
+	recoveredLine int
+	// Metadata read so far. Only used with the WithMetadata option.
+	metadata map[string]string
+	// Units of the columns of the current table, indexed by qualified name.
+	// Only used with the WithUnits option.
+	units map[string]string
+	// Statistics of the current table.
+	stats TableStats
+	// Whether the current row was reused from previously decoded rows rather
+	// than decoded, e.g., by LoadDelta, in which case its AfterDecode hooks
+	// are not called again.
+	reusedRow bool
+	// Qualified names of the columns of the empty cells of the current row,
+	// which are counted in the statistics only if the row is decoded.
+	emptyColumns []string
+	// Indices of the fields of `T` of the components given with
+	// WithDefaultComponents.
+	defaultComponents []int
+	// Default values of the fields of the components of `T`, given by the
+	// `default` option of their `csvstruct` tag.
+	fieldDefaults []fieldDefault
+	// Offset in the CSV data up to which the bytes read have been waited for by
+	// the rate limiter given with WithByteRateLimit.
+	limitedOffset int64
+	// Whether the underlying CSV reader failed to read the most recent row for
+	// reasons other than malformed CSV data, e.g., I/O errors, so reading can't
+	// continue with the next row.
+	readFailed bool
+	// Cells of the ignored columns of the most recently read row, indexed by
+	// column name.
+	unknownCells map[string]string
+	// Index of the field of `T` that captures the unknown columns, or -1 if
+	// there is none.
+	unknownField int
+	// Whether each cell of the most recently read row was quoted. Only used
+	// with the WithQuoteTracking option.
+	quoted []bool
+	// Hash of the most recently decoded row. Only used with the WithRowHash
+	// option.
+	rowHash uint64
+	// Column and size of the largest cell of the most recently decoded row.
+	// Only used with the WithRowTiming option.
+	largestColumn string
+	largestSize   int
+	// Allocator of the components of the decoded rows, or nil if it hasn't
+	// been created yet. Only used with the WithSlabAllocation option.
+	slabs *slabAllocator
+	// Audit of the columns of the current table, indexed by qualified name.
+	// Only used with the WithAudit option.
+	audit map[string]*columnAudit
+}
+
+// ErrEndOfSection is returned by Read when it reads a section row, which ends
+// the current table. Only used with the WithSections option.
+var ErrEndOfSection = errors.New("end of section")
+
+// parseSectionRow parses a section row, e.g., '[MySection]', and returns the
+// section name and true, or false if the row is not a section row. Cells other
+// than the first must be empty.
+func parseSectionRow(row []string) (string, bool) {
+	if len(row) == 0 || len(row[0]) < 3 || !strings.HasPrefix(row[0], "[") || !strings.HasSuffix(row[0], "]") {
+		return "", false
+	}
+
+	for _, cell := range row[1:] {
+		if len(cell) > 0 {
+			return "", false
+		}
+	}
+
+	return row[0][1 : len(row[0])-1], true
+}
+
+// ColumnError is a problem with a column of a CSV header.
+type ColumnError struct {
+	// Number of the column, starting at 1.
+	Column int
+	// Name of the column, as given in the CSV header.
+	Name string
+	// The problem.
+	Err error
+	// Similar columns of the schema, if the column is not in the schema, e.g.,
+	// because of a typo. See SuggestColumns.
+	Suggestions []string
+}
+
+func (e *ColumnError) Error() string {
+	if len(e.Suggestions) > 0 {
+		return fmt.Sprintf("column %d (%s): %v; did you mean %s?", e.Column, e.Name, e.Err, strings.Join(e.Suggestions, " or "))
+	}
+	return fmt.Sprintf("column %d (%s): %v", e.Column, e.Name, e.Err)
+}
+
+func (e *ColumnError) Unwrap() error {
+	return e.Err
+}
+
+// ParseError is a problem with a cell of a data row, e.g., a cell that can't be
+// parsed as the type of its field. It's returned by Read, wrapped in other
+// errors in some cases, so it should be retrieved with errors.As.
+type ParseError struct {
+	// Line of the cell in the CSV data, starting at 1.
+	Line int
+	// Number of the column, starting at 1.
+	Column int
+	// Qualified name of the column, e.g., 'MyComponent.MyField'.
+	Name string
+	// Go name of the field that the cell is decoded into, e.g.,
+	// 'MyComponent.MyField', which differs from the column name if the CSV
+	// header is renamed, e.g., with a `csvstruct` tag or a Mapping.
+	Field string
+	// The problem.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d (%s): %v", e.Line, e.Column, e.Name, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// HeaderError is returned by Read when the CSV header has problems, e.g.,
+// columns that don't match any component field. It contains all the problems
+// of the CSV header, rather than just the first, so they can be fixed at once.
+type HeaderError struct {
+	// Problems of the CSV header, in column order.
+	Columns []*ColumnError
+}
+
+func (e *HeaderError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid CSV header:")
+	for _, err := range e.Columns {
+		fmt.Fprintf(&b, "\n%v", err)
+	}
+	return b.String()
+}
+
+func (e *HeaderError) Unwrap() []error {
+	errs := make([]error, len(e.Columns))
+	for i, err := range e.Columns {
+		errs[i] = err
+	}
+	return errs
 }
 
 // createDescriptors creates the column descriptors from the CSV header.
+//
+// If the CSV header has problems, this returns a HeaderError with all of them.
 func (r *Reader[T]) createDescriptors(row []string) error {
 	r.colDescriptors = make([]colDescriptor, 0, len(row))
+	r.header = append([]string(nil), row...)
+
+	r.unknownField = unknownFieldIndex(reflect.TypeFor[T]())
+
+	schema := SchemaFor[T]()
+	if err := r.options.checkSchema(schema); err != nil {
+		return err
+	}
 
-	for _, qualName := range row {
-		componentName, fieldName, err := parseHeaderColumnName(qualName)
+	var headerErr HeaderError
+	for columnNum, column := range row {
+		name := r.options.normalizeColumn(column, schema)
+		descriptor, err := r.createDescriptor(name)
+		if err != nil && r.options.isIgnoredColumn(name, schema, r.unknownField >= 0) {
+			descriptor, err = ignoredDescriptor(r.options.mapping.qualName(name)), nil
+		}
+		if err == nil {
+			descriptor, err = r.checkAccess(columnNum, column, descriptor)
+		}
 		if err != nil {
-			return err
+			columnErr := &ColumnError{Column: columnNum + 1, Name: column, Err: err}
+			if qualName := r.options.mapping.qualName(name); !slices.Contains(schema.Columns, qualName) {
+				columnErr.Suggestions = SuggestColumns(qualName, schema)
+			}
+			headerErr.Columns = append(headerErr.Columns, columnErr)
+		}
+		r.colDescriptors = append(r.colDescriptors, descriptor)
+	}
+
+	for columnNum := range r.colDescriptors {
+		if err := r.resolveUnion(columnNum); err != nil {
+			headerErr.Columns = append(headerErr.Columns, &ColumnError{Column: columnNum + 1, Name: row[columnNum], Err: err})
+		}
+	}
+	headerErr.Columns = append(headerErr.Columns, r.checkRepeatedIndices(row)...)
+	headerErr.Columns = append(headerErr.Columns, r.missingRequiredColumns(len(row))...)
+
+	if len(headerErr.Columns) > 0 {
+		slices.SortStableFunc(headerErr.Columns, func(a, b *ColumnError) int {
+			return a.Column - b.Column
+		})
+		return &headerErr
+	}
+	return nil
+}
+
+// createDescriptor creates the column descriptor of the given CSV header
+// column.
+func (r *Reader[T]) createDescriptor(column string) (colDescriptor, error) {
+	componentName, fieldName, err := parseHeaderColumnName(r.options.mapping.qualName(column))
+	if err != nil {
+		return colDescriptor{}, err
+	}
+
+	sliceName, repeatedIndex, isRepeated, err := parseRepeatedName(componentName)
+	if err != nil {
+		return colDescriptor{}, err
+	}
+
+	field, ok := fieldByColumnName(reflect.TypeFor[T](), sliceName)
+	if !ok {
+		return colDescriptor{}, fmt.Errorf("type %s does not have a field %q", reflect.TypeFor[T]().String(), sliceName)
+	}
+
+	var componentType reflect.Type
+	if isRepeated {
+		if componentType, ok = repeatedStruct(field.Type); !ok {
+			return colDescriptor{}, fmt.Errorf("field %q of type %s is not a repeated component; want a slice of structs or of pointers to structs", sliceName, reflect.TypeFor[T]().String())
 		}
+	} else if componentType, ok = componentStruct(field.Type); !ok {
+		return colDescriptor{}, fmt.Errorf("field %q of type %s is not a component; want a struct or a pointer to a struct", componentName, reflect.TypeFor[T]().String())
+	}
 
-		field, ok := reflect.TypeFor[T]().FieldByName(componentName)
+	descriptor := colDescriptor{componentName: componentName, fieldName: fieldName, componentIndex: field.Index, isRepeated: isRepeated, repeatedIndex: repeatedIndex}
+	if len(fieldName) > 0 {
+		subfield, ok := fieldByColumnName(componentType, fieldName)
+		if !ok && strings.Contains(fieldName, ".") {
+			subfield, ok = nestedFieldByColumnName(componentType, fieldName)
+			descriptor.isNested = ok
+		}
+		if !ok {
+			// Protobuf-generated components can use proto names.
+			if subfield, ok = findProtoField(componentType, fieldName); ok {
+				descriptor.fieldName = subfield.Name
+			}
+		}
+		typ := subfield.Type
 		if !ok {
-			return fmt.Errorf("type %s does not have a field %q", reflect.TypeFor[T]().String(), componentName)
+			subfield, descriptor.mapKey, ok, err = findPatternField(componentType, fieldName)
+			if err != nil {
+				return colDescriptor{}, err
+			}
+			if !ok {
+				return colDescriptor{}, fmt.Errorf("type %s does not have a field %q", field.Type.String(), fieldName)
+			}
+			descriptor.mapField = subfield.Name
+			typ = subfield.Type.Elem()
+		}
+		descriptor.fieldIndex = subfield.Index
+		descriptor.kind = typ.Kind()
+		descriptor.typ = typ
+		descriptor.assetDir, descriptor.isAsset = subfield.Tag.Lookup("asset")
+		descriptor.isRef = typ == reflect.PointerTo(reflect.TypeFor[T]())
+		descriptor.codec = lookupCodec(r.options.codecs, typ)
+		if descriptor.codec == nil {
+			descriptor.codec = newListCodec(r.options.codecs, r.options.parseBool, typ, r.options.listSeparator)
+		}
+		if descriptor.codec == nil {
+			descriptor.codec = newMapCodec(r.options.codecs, r.options.parseBool, typ, r.options.pairSeparator, r.options.keySeparator)
+		}
+		if codec, err := r.options.mapping.converter(descriptor.qualName()); err != nil {
+			return colDescriptor{}, err
+		} else if codec != nil {
+			descriptor.codec = codec
 		}
 
-		var kind reflect.Kind
-		if len(fieldName) > 0 {
-			subfield, ok := field.Type.Elem().FieldByName(fieldName)
+		descriptor.locMode = subfield.Tag.Get("loc")
+		switch descriptor.locMode {
+		case "", "key", "text":
+		default:
+			return colDescriptor{}, fmt.Errorf("field %q of type %s has invalid loc tag %q; want \"key\" or \"text\"", fieldName, field.Type.String(), descriptor.locMode)
+		}
+		if len(descriptor.locMode) > 0 && descriptor.kind != reflect.String {
+			return colDescriptor{}, fmt.Errorf("field %q of type %s has a loc tag but it's not a string", fieldName, field.Type.String())
+		}
+
+		if _, layout := parseTag(subfield); typ == timeType {
+			descriptor.timeLayout = cmp.Or(layout, r.options.timeLayout, time.RFC3339)
+		} else if len(layout) > 0 {
+			return colDescriptor{}, fmt.Errorf("field %q of type %s has a layout in its csvstruct tag but it's not a time.Time", fieldName, field.Type.String())
+		}
+
+		descriptor.required = hasColumnOption(subfield, "required")
+		if enum, ok := columnOptionValue(subfield, "enum"); ok {
+			descriptor.enum = strings.Split(enum, "|")
+		}
+
+		descriptor.unit = subfield.Tag.Get("unit")
+		if len(descriptor.unit) > 0 {
+			switch descriptor.kind {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			default:
+				return colDescriptor{}, fmt.Errorf("field %q of type %s has a unit tag but it's not a number", fieldName, field.Type.String())
+			}
+			if _, ok := knownUnits[descriptor.unit]; !ok {
+				return colDescriptor{}, fmt.Errorf("field %q of type %s has unknown unit %q", fieldName, field.Type.String(), descriptor.unit)
+			}
+		}
+
+		if typeField, ok := subfield.Tag.Lookup("union"); ok {
+			if descriptor.kind != reflect.Interface {
+				return colDescriptor{}, fmt.Errorf("field %q of type %s has a union tag but it's not an interface", fieldName, field.Type.String())
+			}
+
+			types, ok := r.options.unions[descriptor.qualName()]
 			if !ok {
-				return fmt.Errorf("type %s does not have a field %q", field.Type.String(), fieldName)
+				return colDescriptor{}, fmt.Errorf("field %q of type %s has a union tag but no types were given with WithUnion(%q, ...)", fieldName, field.Type.String(), descriptor.qualName())
 			}
-			kind = subfield.Type.Kind()
+
+			descriptor.union = &union{typeField: typeField, types: types}
 		}
 
-		r.colDescriptors = append(r.colDescriptors, colDescriptor{kind, componentName, fieldName})
+		// Other types, e.g., pointers to numbers of proto3 optional fields,
+		// would otherwise be silently left empty.
+		if descriptor.union == nil && descriptor.codec == nil && !descriptor.isRef && !isWritableField(typ) {
+			return colDescriptor{}, fmt.Errorf("field %q of type %s has unsupported type %s; want a number, bool, string, time, or a type with a codec", fieldName, field.Type.String(), typ.String())
+		}
+	}
+
+	return descriptor, nil
+}
+
+// cellError annotates `err` with the location of the cell in column
+// `columnNum` of the most recently read row.
+func (r *Reader[T]) cellError(columnNum int, err error) error {
+	return r.parseError(r.fieldLine(columnNum), columnNum, &r.colDescriptors[columnNum], err)
+}
+
+// fieldLine returns the line number of the cell in column `columnNum` of the
+// most recently read row.
+func (r *Reader[T]) fieldLine(columnNum int) int {
+	if r.recoveredLine > 0 {
+		return r.recoveredLine
+	}
+	line, _ := r.reader.FieldPos(columnNum)
+	return r.baseLine + line
+}
+
+// parseError annotates `err` with the given line and column, whose field is
+// described by `descriptor`.
+func (r *Reader[T]) parseError(line, columnNum int, descriptor *colDescriptor, err error) *ParseError {
+	return &ParseError{
+		Line:   line,
+		Column: columnNum + 1,
+		Name:   descriptor.qualName(),
+		Field:  fieldPath(reflect.TypeFor[T](), descriptor),
+		Err:    err,
+	}
+}
+
+// fieldPath returns the Go name of the field described by `descriptor` in the
+// type `typ`, e.g., 'MyComponent.MyField', or the name of the component if the
+// column only marks the presence of the component.
+func fieldPath(typ reflect.Type, descriptor *colDescriptor) string {
+	if len(descriptor.componentIndex) == 0 {
+		return ""
+	}
+
+	component := typ.FieldByIndex(descriptor.componentIndex)
+	name, componentType := component.Name, component.Type
+	if descriptor.isRepeated {
+		name, componentType = fmt.Sprintf("%s[%d]", name, descriptor.repeatedIndex), componentType.Elem()
+	}
+	if len(descriptor.fieldIndex) == 0 {
+		return name
+	}
+
+	componentType, _ = componentStruct(componentType)
+	if !descriptor.isNested {
+		return name + "." + componentType.FieldByIndex(descriptor.fieldIndex).Name
+	}
+
+	for i := range descriptor.fieldIndex {
+		name += "." + componentType.FieldByIndex(descriptor.fieldIndex[:i+1]).Name
+	}
+	return name
+}
+
+// intError returns the error of parsing `cell` as an integer of type `typ`,
+// which reports overflows with the type instead of the range error of strconv.
+func intError(cell string, typ reflect.Type, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return fmt.Errorf("value %s overflows %s", cell, typ.String())
 	}
+	return err
+}
 
+// checkAsset checks that the asset referenced by `cell` exists in the asset
+// filesystem, if one was given.
+func (r *Reader[T]) checkAsset(descriptor *colDescriptor, cell string) error {
+	if r.options.assetFS == nil {
+		return nil
+	}
+
+	name := path.Join(descriptor.assetDir, cell)
+	if _, err := fs.Stat(r.options.assetFS, name); err != nil {
+		return fmt.Errorf("asset %q not found: %w", name, err)
+	}
 	return nil
 }
 
+// localize validates the localization key in `cell` against the string table,
+// if one was given, and returns the cell contents that should be decoded.
+func (r *Reader[T]) localize(descriptor *colDescriptor, cell string) (string, error) {
+	if r.options.stringTable == nil {
+		return cell, nil
+	}
+
+	text, ok := r.options.stringTable.Lookup(cell)
+	if !ok {
+		return "", fmt.Errorf("localization key %q not found", cell)
+	}
+
+	if descriptor.locMode == "text" {
+		return text, nil
+	}
+	return cell, nil
+}
+
 // parseRow parses a data row into `t`.
 func (r *Reader[T]) parseRow(t *T) error {
+	start := r.reader.InputOffset()
+	r.recoveredLine = 0
+	r.readFailed = false
+	if err := r.setRowDeadline(); err != nil {
+		r.readFailed = true
+		return err
+	}
 	row, err := r.reader.Read()
+	if err == nil {
+		err = r.trackQuotes(start)
+	} else {
+		var parseErr *csv.ParseError
+		r.readFailed = !errors.As(err, &parseErr)
+		r.quoted = r.quoted[:0]
+		row, err = r.recoverRecord(start, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if r.options.sections {
+		if name, ok := parseSectionRow(row); ok {
+			r.emit(Event{Kind: EventTableEnded, Line: r.fieldLine(0)})
+			r.section = name
+			return ErrEndOfSection
+		}
+	}
+
+	if r.options.blankRowSeparators && isBlankRow(row) {
+		return r.nextTable(t)
+	}
+
+	row, err = r.trimTrailingColumns(row)
 	if err != nil {
 		return err
 	}
 
+	if len(r.options.middleware) == 0 {
+		return r.decodeRecord(row, t)
+	}
+
+	next := func(row *Row) error {
+		return r.decodeRecord(row.Cells, t)
+	}
+	for i := len(r.options.middleware) - 1; i >= 0; i-- {
+		next = r.options.middleware[i](next)
+	}
+	return next(&Row{Line: r.fieldLine(0), Header: r.header, Cells: row, Value: t})
+}
+
+// trimTrailingColumns removes the cells of `row` that are beyond the CSV
+// header, which must be empty, if the WithTrailingEmptyColumns option was
+// given. Otherwise, it returns `row` unchanged.
+func (r *Reader[T]) trimTrailingColumns(row []string) ([]string, error) {
+	if !r.options.trailingEmptyColumns || len(row) <= len(r.colDescriptors) {
+		return row, nil
+	}
+
+	for columnNum := len(r.colDescriptors); columnNum < len(row); columnNum++ {
+		if len(row[columnNum]) > 0 {
+			return nil, fmt.Errorf("line %d, column %d: unnamed trailing column has non-empty cell %q", r.fieldLine(columnNum), columnNum+1, row[columnNum])
+		}
+	}
+	return row[:len(r.colDescriptors)], nil
+}
+
+// decodeRecord decodes the cells of a data row into `t`.
+func (r *Reader[T]) decodeRecord(row []string, t *T) error {
+	if len(row) > len(r.colDescriptors) {
+		return fmt.Errorf("line %d: row has %d cells but the CSV header has %d columns", r.fieldLine(0), len(row), len(r.colDescriptors))
+	}
+
 	var def T
 	*t = def
+	r.refs = r.refs[:0]
+	r.unknownCells = nil
+
+	if r.options.rowHash {
+		r.rowHash = r.hashRow(row)
+	}
+
+	if r.options.rowTiming {
+		r.largestCell(row)
+	}
+
+	if r.options.audit {
+		r.auditRow(row)
+	}
+
+	if r.options.completeComponents {
+		if err := r.checkCompleteComponents(row); err != nil {
+			return err
+		}
+	}
 
-	data := map[string]interface{}{}
+	root := reflect.ValueOf(t).Elem()
 	for columnNum, cell := range row {
+		descriptor := r.colDescriptors[columnNum]
+		if descriptor.ignored {
+			if !descriptor.forbidden {
+				if r.unknownCells == nil {
+					r.unknownCells = map[string]string{}
+				}
+				r.unknownCells[r.header[columnNum]] = cell
+			}
+			continue
+		}
+
+		if r.isEmptyCell(&descriptor, cell) {
+			if descriptor.required {
+				return r.cellError(columnNum, errors.New("required field is empty"))
+			}
+			r.emptyColumns = append(r.emptyColumns, descriptor.qualName())
+			continue
+		}
 		if len(cell) == 0 {
+			cell = "0"
+		}
+
+		cell, err := r.checkUTF8(columnNum, cell)
+		if err != nil {
+			return r.cellError(columnNum, err)
+		}
+
+		if descriptor.enum != nil && !slices.Contains(descriptor.enum, cell) {
+			return r.cellError(columnNum, fmt.Errorf("invalid value %q; want one of %q", cell, descriptor.enum))
+		}
+
+		if descriptor.isRef {
+			if !strings.HasPrefix(cell, "@") {
+				return r.cellError(columnNum, fmt.Errorf("expected row reference, e.g., '@MyRow'; got %q", cell))
+			}
+
+			r.refs = append(r.refs, rowRef{r.fieldLine(columnNum), columnNum, &r.colDescriptors[columnNum], cell[1:]})
 			continue
 		}
 
-		descriptor := r.colDescriptors[columnNum]
+		if descriptor.isAsset {
+			if err := r.checkAsset(&descriptor, cell); err != nil {
+				return r.cellError(columnNum, err)
+			}
+		}
+
+		if len(descriptor.locMode) > 0 {
+			var err error
+			cell, err = r.localize(&descriptor, cell)
+			if err != nil {
+				return r.cellError(columnNum, err)
+			}
+		}
 
 		var value interface{}
-		switch descriptor.kind {
-		case reflect.Int, reflect.Int32, reflect.Int64:
-			number, err := strconv.Atoi(cell)
+		if descriptor.union != nil {
+			var err error
+			value, err = r.parseUnion(&descriptor, row, cell)
 			if err != nil {
-				return err
+				return r.cellError(columnNum, err)
 			}
-			value = number
-		case reflect.Float32:
-			number, err := strconv.ParseFloat(cell, 32)
+		} else if descriptor.codec != nil {
+			dst := reflect.New(descriptor.typ).Elem()
+			if err := descriptor.codec.Decode(cell, dst); err != nil {
+				return r.cellError(columnNum, err)
+			}
+			value = dst.Interface()
+		} else if descriptor.typ == durationType {
+			duration, err := time.ParseDuration(cell)
 			if err != nil {
-				return err
+				return r.cellError(columnNum, err)
 			}
-			value = number
-		case reflect.Float64:
-			number, err := strconv.ParseFloat(cell, 64)
+			value = duration
+		} else if len(descriptor.timeLayout) > 0 {
+			parsed, err := time.Parse(descriptor.timeLayout, cell)
 			if err != nil {
-				return err
+				return r.cellError(columnNum, err)
+			}
+			value = parsed
+		} else {
+			switch descriptor.kind {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				number, err := strconv.ParseInt(cell, 10, descriptor.typ.Bits())
+				if err != nil {
+					return r.cellError(columnNum, intError(cell, descriptor.typ, err))
+				}
+				value = number
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				number, err := strconv.ParseUint(cell, 10, descriptor.typ.Bits())
+				if err != nil {
+					return r.cellError(columnNum, intError(cell, descriptor.typ, err))
+				}
+				value = number
+			case reflect.Float32:
+				number, err := strconv.ParseFloat(cell, 32)
+				if err != nil {
+					return r.cellError(columnNum, err)
+				}
+				value = number
+			case reflect.Float64:
+				number, err := strconv.ParseFloat(cell, 64)
+				if err != nil {
+					return r.cellError(columnNum, err)
+				}
+				value = number
+			case reflect.Bool:
+				b, err := r.options.parseBool(cell)
+				if err != nil {
+					return r.cellError(columnNum, err)
+				}
+				value = b
+			case reflect.String:
+				value = cell
 			}
-			value = number
-		case reflect.String:
-			value = cell
 		}
 
-		if obj, ok := data[descriptor.componentName]; ok {
-			obj.(map[string]interface{})[descriptor.fieldName] = value
-		} else {
-			data[descriptor.componentName] = map[string]interface{}{descriptor.fieldName: value}
+		if descriptor.unitScale != 0 {
+			var err error
+			value, err = scaleValue(value, descriptor.unitScale, descriptor.typ)
+			if err != nil {
+				return r.cellError(columnNum, err)
+			}
+		}
+
+		if r.options.slabSize > 0 {
+			r.slabAllocator().allocDescriptorComponent(root, &descriptor)
+		}
+		if err := descriptor.setField(root, value); err != nil {
+			return r.cellError(columnNum, err)
 		}
 	}
 
-	return mapstructure.Decode(data, t)
+	if r.unknownField >= 0 && r.unknownCells != nil {
+		root.Field(r.unknownField).Set(reflect.ValueOf(maps.Clone(r.unknownCells)))
+	}
+
+	r.allocateDefaultComponents(t)
+	r.applyFieldDefaults(row, t)
+	return nil
 }
 
 // Clears part of the internal state so that this is ready to continue parsing,
@@ -142,6 +813,84 @@ func (r *Reader[T]) Clear() {
 	r.permanentErr = nil
 	r.hasDescriptors = false
 	r.colDescriptors = nil
+	r.header = nil
+}
+
+// readHeader reads the CSV header row and creates the column descriptors.
+func (r *Reader[T]) readHeader() error {
+	if err := r.setRowDeadline(); err != nil {
+		return err
+	}
+
+	row, err := r.readHeaderRow()
+	if err == io.EOF {
+		return fmt.Errorf("failed to read CSV header: %v", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if r.options.sections {
+		if name, ok := parseSectionRow(row); ok {
+			r.section = name
+			r.emit(Event{Kind: EventRowSkipped, Line: r.fieldLine(0)})
+			return r.readHeader()
+		}
+	}
+
+	return r.parseHeader(row)
+}
+
+// compileHeader creates the column descriptors from the CSV header `row` and
+// resolves the default components and the default values of the fields, i.e.,
+// all the state of the current table that derives from the CSV header and
+// that doesn't need to read more CSV data.
+func (r *Reader[T]) compileHeader(row []string) error {
+	if err := r.checkSlabSize(); err != nil {
+		return err
+	}
+	if err := r.createDescriptors(row); err != nil {
+		return err
+	}
+
+	var err error
+	if r.defaultComponents, err = resolveDefaultComponents[T](r.options.defaultComponents); err != nil {
+		return err
+	}
+	r.fieldDefaults, err = r.resolveFieldDefaults()
+	return err
+}
+
+// parseHeader creates the column descriptors from the CSV header `row`, which
+// was just read by the underlying CSV reader.
+func (r *Reader[T]) parseHeader(row []string) error {
+	if r.options.trailingEmptyColumns {
+		for len(row) > 0 && len(row[len(row)-1]) == 0 {
+			row = row[:len(row)-1]
+		}
+	}
+
+	line := r.fieldLine(0)
+	r.emit(Event{Kind: EventTableStarted, Line: line})
+	r.audit = nil
+
+	if err := r.compileHeader(row); err != nil {
+		r.Clear()
+		r.permanentErr = err
+		return err
+	}
+
+	if r.options.units {
+		if err := r.readUnits(); err != nil {
+			r.Clear()
+			r.permanentErr = err
+			return err
+		}
+	}
+
+	r.hasDescriptors = true
+	r.emit(Event{Kind: EventHeaderParsed, Line: line, Header: r.header})
+	return nil
 }
 
 // Reads the next CSV row and returns typed data.
@@ -156,30 +905,52 @@ func (r *Reader[T]) Clear() {
 // the first return value is always nil. In other words, this either returns
 // valid data or it returns an error, but never both simultaneously.
 func (r *Reader[T]) Read(t *T) error {
+	return r.read(context.Background(), t)
+}
+
+// read implements Read. The context `ctx` is used to wait for the rate
+// limiters given with WithRowRateLimit and WithByteRateLimit.
+func (r *Reader[T]) read(ctx context.Context, t *T) error {
 	if r.permanentErr != nil {
 		return r.permanentErr
 	}
 
+	if err := r.waitRateLimits(ctx); err != nil {
+		return err
+	}
+
 	if !r.hasDescriptors {
-		row, err := r.reader.Read()
-		if err == io.EOF {
-			return fmt.Errorf("failed to read CSV header: %v", err)
-		}
-		if err != nil {
+		if err := r.readHeader(); err != nil {
 			return err
 		}
+	}
 
-		if err := r.createDescriptors(row); err != nil {
-			r.Clear()
-			r.permanentErr = err
-			return err
+	// Read a CSV row and parse it based on the descriptors.
+	var err error
+	var start time.Time
+	for {
+		if r.options.rowTiming {
+			start = time.Now()
+		}
+		r.emptyColumns = r.emptyColumns[:0]
+		r.reusedRow = false
+		err = r.parseRow(t)
+		if err == nil && !r.reusedRow {
+			if err = afterDecodeRow(t); err != nil {
+				err = fmt.Errorf("line %d: %w", r.fieldLine(0), err)
+			}
+		}
+		if !errors.Is(err, ErrSkipRow) && !r.skipInvalidRow(err) {
+			break
 		}
 
-		r.hasDescriptors = true
+		r.emit(Event{Kind: EventRowSkipped, Line: r.fieldLine(0), Err: err})
+		var def T
+		*t = def
 	}
 
-	// Read a CSV row and parse it based on the descriptors.
-	if err := r.parseRow(t); err == io.EOF {
+	if err == io.EOF {
+		r.emit(Event{Kind: EventTableEnded})
 		r.Clear()
 		r.permanentErr = err
 		return err
@@ -189,13 +960,139 @@ func (r *Reader[T]) Read(t *T) error {
 		return err
 	}
 
+	r.countEmptyCells()
+	r.emit(Event{Kind: EventRowDecoded, Line: r.fieldLine(0), Row: t, Duration: r.timeRow(start)})
 	return nil
 }
 
+// ReadAll reads all the remaining rows of the current table. Like
+// csv.Reader.ReadAll, a successful call returns a nil error, not io.EOF, since
+// reading until the end of the data is not an error.
+//
+// When an error is returned, the rows read so far are not returned.
+func (r *Reader[T]) ReadAll() ([]T, error) {
+	var rows []T
+	for {
+		var t T
+		err := r.Read(&t)
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, t)
+	}
+}
+
+// Header returns the CSV header of the current table, as read from the CSV
+// data, or nil if it hasn't been read yet.
+func (r *Reader[T]) Header() []string {
+	return r.header
+}
+
+// ColumnInfo describes a column of the CSV header of the current table.
+type ColumnInfo struct {
+	// Index of the column in the CSV header, starting at 0.
+	Index int
+	// Name of the column, as given in the CSV header.
+	Name string
+	// Component and field of the column, after mappings, e.g., 'MyComponent'
+	// and 'MyField'. The field is empty if the column only marks the presence
+	// of the component.
+	Component string
+	Field     string
+	// Kind of the field, or reflect.Invalid if the column only marks the
+	// presence of the component or if it's ignored.
+	Kind reflect.Kind
+	// Whether the column is ignored because it's not in `T`, e.g., with the
+	// WithIgnoreUnknownColumns option.
+	Ignored bool
+}
+
+// Columns returns the columns of the CSV header of the current table, in
+// order, e.g., so that tools can validate or display the active schema, or nil
+// if the CSV header hasn't been read yet.
+func (r *Reader[T]) Columns() []ColumnInfo {
+	if !r.hasDescriptors {
+		return nil
+	}
+
+	columns := make([]ColumnInfo, len(r.colDescriptors))
+	for i := range r.colDescriptors {
+		descriptor := &r.colDescriptors[i]
+		columns[i] = ColumnInfo{
+			Index:     i,
+			Name:      r.header[i],
+			Component: descriptor.componentName,
+			Field:     descriptor.fieldName,
+			Kind:      descriptor.kind,
+			Ignored:   descriptor.ignored,
+		}
+	}
+	return columns
+}
+
+// Section returns the name of the most recent section row, e.g., 'MySection'
+// for '[MySection]', or the empty string if there is none. Only used with the
+// WithSections option.
+func (r *Reader[T]) Section() string {
+	return r.section
+}
+
+// Clone returns a new reader that reads data rows from `reader` using the
+// column descriptors and the options of this reader, e.g., to read another
+// shard of the same data, which has the same CSV header but doesn't contain it,
+// without resolving the CSV header again.
+//
+// Like Read, this expects the first row to be the CSV header unless the header
+// was already read. The new reader reads data rows until the end of its data,
+// and after Clear, it expects a CSV header like any other reader.
+func (r *Reader[T]) Clone(reader *csv.Reader) (*Reader[T], error) {
+	if r.permanentErr != nil {
+		return nil, r.permanentErr
+	}
+
+	if !r.hasDescriptors {
+		if err := r.readHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	reader.ReuseRecord = true
+	if r.options.sections || r.options.blankRowSeparators {
+		reader.FieldsPerRecord = -1
+	}
+
+	return &Reader[T]{
+		reader:            reader,
+		hasDescriptors:    true,
+		colDescriptors:    slices.Clone(r.colDescriptors),
+		header:            r.header,
+		options:           r.options,
+		section:           r.section,
+		metadata:          maps.Clone(r.metadata),
+		units:             r.units,
+		defaultComponents: r.defaultComponents,
+		fieldDefaults:     r.fieldDefaults,
+		unknownField:      r.unknownField,
+	}, nil
+}
+
 // NewReader returns a new reader using the given `reader` as the underlying CSV
 // reader. The type `T` is the schema that is used to parse the data.
-func NewReader[T any](reader *csv.Reader) *Reader[T] {
+//
+// The reader can be configured with options, e.g., WithAssetFS.
+func NewReader[T any](reader *csv.Reader, opts ...Option) *Reader[T] {
 	reader.ReuseRecord = true
 	csvreader := &Reader[T]{reader: reader}
+	for _, opt := range opts {
+		opt(&csvreader.options)
+	}
+	if csvreader.options.sections || csvreader.options.blankRowSeparators {
+		// Tables in different sections can have different numbers of columns.
+		reader.FieldsPerRecord = -1
+	}
 	return csvreader
 }
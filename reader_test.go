@@ -2,12 +2,17 @@ package csvstruct_test
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/jabolopes/csvstruct"
 )
 
@@ -39,8 +44,9 @@ type Prefab struct {
 func ExampleReader() {
 	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
 
-	var prefab Prefab
+	var prefabs []Prefab
 	for {
+		var prefab Prefab
 		err := reader.Read(&prefab)
 		if err == io.EOF {
 			break
@@ -49,23 +55,37 @@ func ExampleReader() {
 			panic(err)
 		}
 
-		fmt.Printf("%#v\n", prefab.Info)
-		fmt.Printf("%#v\n", prefab.Attributes)
-		fmt.Printf("%#v\n", prefab.Player)
+		prefabs = append(prefabs, prefab)
+	}
+
+	if err := csvstruct.Dump(os.Stdout, prefabs); err != nil {
+		panic(err)
 	}
 
-	// Output: &csvstruct_test.Info{Name:"Alex", Class:"Fighter"}
-	// &csvstruct_test.Attributes{HP:100, Damage:10}
-	// (*csvstruct_test.Player)(nil)
-	// &csvstruct_test.Info{Name:"Jayden", Class:"Wizard"}
-	// &csvstruct_test.Attributes{HP:90, Damage:20}
-	// (*csvstruct_test.Player)(nil)
-	// &csvstruct_test.Info{Name:"Mary", Class:"Queen"}
-	// (*csvstruct_test.Attributes)(nil)
-	// (*csvstruct_test.Player)(nil)
-	// &csvstruct_test.Info{Name:"Player", Class:""}
-	// (*csvstruct_test.Attributes)(nil)
-	// &csvstruct_test.Player{}
+	// Output:
+	// #1
+	//   Info
+	//     Name: "Alex"
+	//     Class: "Fighter"
+	//   Attributes
+	//     HP: 100
+	//     Damage: 10
+	// #2
+	//   Info
+	//     Name: "Jayden"
+	//     Class: "Wizard"
+	//   Attributes
+	//     HP: 90
+	//     Damage: 20
+	// #3
+	//   Info
+	//     Name: "Mary"
+	//     Class: "Queen"
+	// #4
+	//   Info
+	//     Name: "Player"
+	//     Class: ""
+	//   Player
 }
 
 func TestReader(t *testing.T) {
@@ -110,3 +130,383 @@ func TestReader(t *testing.T) {
 		want = want[1:]
 	}
 }
+
+func TestReaderHeaderError(t *testing.T) {
+	const data = `Info.Name,Info.Level,Stats.HP,Attributes.HP,Attributes.Mana
+Alex,1,100,100,10
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)))
+
+	var got Prefab
+	err := reader.Read(&got)
+
+	var headerErr *csvstruct.HeaderError
+	if !errors.As(err, &headerErr) {
+		t.Fatalf("Read() err = %v; want %T", err, headerErr)
+	}
+
+	var columns []string
+	for _, columnErr := range headerErr.Columns {
+		columns = append(columns, fmt.Sprintf("%d %s", columnErr.Column, columnErr.Name))
+	}
+
+	want := []string{"2 Info.Level", "3 Stats.HP", "5 Attributes.Mana"}
+	if diff := cmp.Diff(want, columns); diff != "" {
+		t.Errorf("Read() columns diff = %v", diff)
+	}
+}
+
+func TestReaderClone(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
+
+	shard := "Zoe,Rogue,70,15,\n"
+	clone, err := reader.Clone(csv.NewReader(strings.NewReader(shard)))
+	if err != nil {
+		t.Fatalf("Clone() err = %v; want %v", err, nil)
+	}
+
+	var got Prefab
+	if err := clone.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Prefab{&Info{"Zoe", "Rogue"}, &Attributes{70, 15}, nil}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+
+	if err := clone.Read(&got); err != io.EOF {
+		t.Errorf("Read() err = %v; want %v", err, io.EOF)
+	}
+
+	// The original reader continues with the first data row.
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(testPrefabs[0], got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+}
+
+type Movement struct {
+	Speed        float64
+	Acceleration float32
+}
+
+type Vehicle struct {
+	Movement *Movement
+}
+
+func TestReaderFloat(t *testing.T) {
+	const data = `Movement.Speed,Movement.Acceleration
+12.5,0.25
+-3e2,
+`
+
+	want := []Vehicle{
+		{&Movement{12.5, 0.25}},
+		{&Movement{-300, 0}},
+	}
+
+	reader := csvstruct.NewReader[Vehicle](csv.NewReader(strings.NewReader(data)))
+
+	for _, want := range want {
+		var got Vehicle
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Read() diff = %v", diff)
+		}
+	}
+}
+
+func TestReaderFloat_Error(t *testing.T) {
+	const data = `Movement.Speed,Movement.Acceleration
+12.5,fast
+`
+
+	reader := csvstruct.NewReader[Vehicle](csv.NewReader(strings.NewReader(data)))
+
+	var got Vehicle
+	err := reader.Read(&got)
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2, column 2 (Movement.Acceleration): ") {
+		t.Errorf("Read() err = %v; want error at line 2, column 2", err)
+	}
+}
+
+type Sizes struct {
+	I8  int8
+	I16 int16
+	U   uint
+	U8  uint8
+	U16 uint16
+	U32 uint32
+	U64 uint64
+}
+
+type Packet struct {
+	Sizes *Sizes
+}
+
+func TestReaderIntKinds(t *testing.T) {
+	const data = `Sizes.I8,Sizes.I16,Sizes.U,Sizes.U8,Sizes.U16,Sizes.U32,Sizes.U64
+-128,32767,1,255,65535,4294967295,18446744073709551615
+`
+
+	reader := csvstruct.NewReader[Packet](csv.NewReader(strings.NewReader(data)))
+
+	var got Packet
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Packet{&Sizes{-128, 32767, 1, 255, 65535, 4294967295, 18446744073709551615}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+}
+
+func TestReaderIntKinds_Overflow(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"Sizes.I8\n128\n", "line 2, column 1 (Sizes.I8): value 128 overflows int8"},
+		{"Sizes.U8\n256\n", "line 2, column 1 (Sizes.U8): value 256 overflows uint8"},
+		{"Sizes.U16\n-1\n", `line 2, column 1 (Sizes.U16): strconv.ParseUint: parsing "-1": invalid syntax`},
+	}
+
+	for _, test := range tests {
+		reader := csvstruct.NewReader[Packet](csv.NewReader(strings.NewReader(test.data)))
+
+		var got Packet
+		if err := reader.Read(&got); err == nil || err.Error() != test.want {
+			t.Errorf("Read() err = %v; want %v", err, test.want)
+		}
+	}
+}
+
+func TestReaderReadAll(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(testPrefabs, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestReaderReadAll_Error(t *testing.T) {
+	const data = `Info.Name,Attributes.HP
+Alex,100
+Jayden,lots
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)))
+
+	got, err := reader.ReadAll()
+	if err == nil {
+		t.Fatalf("ReadAll() err = %v; want error", err)
+	}
+	if got != nil {
+		t.Errorf("ReadAll() = %v; want %v", got, nil)
+	}
+}
+
+type Stamina struct {
+	Max int `csvstruct:"max"`
+}
+
+type Runner struct {
+	Stamina *Stamina `csvstruct:"stamina"`
+}
+
+func TestReaderParseError(t *testing.T) {
+	const data = `stamina.max
+100
+lots
+`
+
+	reader := csvstruct.NewReader[Runner](csv.NewReader(strings.NewReader(data)))
+
+	var got Runner
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	err := reader.Read(&got)
+
+	var parseErr *csvstruct.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Read() err = %v; want %T", err, parseErr)
+	}
+
+	want := csvstruct.ParseError{Line: 3, Column: 1, Name: "stamina.max", Field: "Stamina.Max"}
+	if diff := cmp.Diff(want, *parseErr, cmpopts.IgnoreFields(csvstruct.ParseError{}, "Err")); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Errorf("Read() err = %v; want %T", err, numErr)
+	}
+}
+
+func TestReaderColumns(t *testing.T) {
+	const data = `Info.Name,Notes,Attributes.HP,Player
+Alex,tank,100,
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithIgnoreUnknownColumns())
+
+	if got := reader.Columns(); got != nil {
+		t.Errorf("Columns() = %v; want %v", got, nil)
+	}
+
+	var prefab Prefab
+	if err := reader.Read(&prefab); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := []csvstruct.ColumnInfo{
+		{Index: 0, Name: "Info.Name", Component: "Info", Field: "Name", Kind: reflect.String},
+		{Index: 1, Name: "Notes", Component: "Notes", Ignored: true},
+		{Index: 2, Name: "Attributes.HP", Component: "Attributes", Field: "HP", Kind: reflect.Int},
+		{Index: 3, Name: "Player", Component: "Player"},
+	}
+	if diff := cmp.Diff(want, reader.Columns()); diff != "" {
+		t.Errorf("Columns() diff = %v", diff)
+	}
+
+	wantHeader := []string{"Info.Name", "Notes", "Attributes.HP", "Player"}
+	if diff := cmp.Diff(wantHeader, reader.Header()); diff != "" {
+		t.Errorf("Header() diff = %v", diff)
+	}
+}
+
+type PlainPrefab struct {
+	Info       Info
+	Attributes *Attributes
+	Player     Player
+}
+
+func TestReaderValueComponents(t *testing.T) {
+	reader := csvstruct.NewReader[PlainPrefab](csv.NewReader(strings.NewReader(testData)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	// Value components are always present, and pointer components are nil
+	// unless one of their cells is non-empty.
+	want := []PlainPrefab{
+		{Info{"Alex", "Fighter"}, &Attributes{100, 10}, Player{}},
+		{Info{"Jayden", "Wizard"}, &Attributes{90, 20}, Player{}},
+		{Info{"Mary", "Queen"}, nil, Player{}},
+		{Info{"Player", ""}, nil, Player{}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestReaderPresenceColumn(t *testing.T) {
+	const data = `Info.Name,Attributes,Attributes.HP
+Alex,1,
+Jayden,,90
+Mary,,
+Sam,1,80
+`
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Prefab{
+		{Info: &Info{Name: "Alex"}, Attributes: &Attributes{}},
+		{Info: &Info{Name: "Jayden"}, Attributes: &Attributes{HP: 90}},
+		{Info: &Info{Name: "Mary"}},
+		{Info: &Info{Name: "Sam"}, Attributes: &Attributes{HP: 80}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+type Bounty struct {
+	Title  string `csvstruct:"required"`
+	Reward int    `csvstruct:"reward,required"`
+	Notes  string
+}
+
+type Board struct {
+	Bounty *Bounty
+}
+
+func TestReaderRequiredField(t *testing.T) {
+	const data = `Bounty.Title,Bounty.reward,Bounty.Notes
+Rescue,100,
+Escort,,slow
+`
+	reader := csvstruct.NewReader[Board](csv.NewReader(strings.NewReader(data)))
+
+	var got Board
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(Board{&Bounty{"Rescue", 100, ""}}, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+
+	want := "line 3, column 2 (Bounty.reward): required field is empty"
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
+
+func TestReaderRequiredField_MissingColumn(t *testing.T) {
+	reader := csvstruct.NewReader[Board](csv.NewReader(strings.NewReader("Bounty.Title,Bounty.Notes\nRescue,\n")))
+
+	want := "invalid CSV header:\ncolumn 3 (Bounty.reward): required column is missing"
+	var got Board
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
+
+func TestReaderEnum(t *testing.T) {
+	const data = `Relic.Name,Relic.Rarity
+Sword,rare
+Axe,
+Bow,legendary
+`
+	reader := csvstruct.NewReader[Vault](csv.NewReader(strings.NewReader(data)))
+
+	for _, want := range []Vault{
+		{Relic: &Relic{Name: "Sword", Rarity: "rare", Level: 1}},
+		{Relic: &Relic{Name: "Axe", Rarity: "common", Level: 1}},
+	} {
+		var got Vault
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Read() diff = %v", diff)
+		}
+	}
+
+	var got Vault
+	want := `line 4, column 2 (Relic.Rarity): invalid value "legendary"; want one of ["common" "rare" "epic"]`
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
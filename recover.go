@@ -0,0 +1,111 @@
+package csvstruct
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// splitRecord splits a CSV record that encoding/csv failed to parse because
+// of malformed quotes, e.g., 'a,b"c,d' or '"a"b",c', on a best-effort basis.
+//
+// A field that starts with a quote ends at the first quote that is followed by
+// the separator or the end of the line, and within it, escaped quotes ("") are
+// unescaped and other quotes are kept. Other fields end at the separator and
+// their quotes are kept.
+func splitRecord(line string, comma rune) []string {
+	line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+	sep := string(comma)
+
+	var fields []string
+	for {
+		if !strings.HasPrefix(line, `"`) {
+			field, rest, ok := strings.Cut(line, sep)
+			fields = append(fields, field)
+			if !ok {
+				return fields
+			}
+			line = rest
+			continue
+		}
+
+		var field strings.Builder
+		i := 1
+		for ; i < len(line); i++ {
+			if line[i] != '"' {
+				field.WriteByte(line[i])
+				continue
+			}
+
+			if strings.HasPrefix(line[i+1:], `"`) {
+				field.WriteByte('"')
+				i++
+				continue
+			}
+
+			if i+1 == len(line) || strings.HasPrefix(line[i+1:], sep) {
+				break
+			}
+			field.WriteByte('"')
+		}
+		fields = append(fields, field.String())
+
+		if i+1 >= len(line) {
+			return fields
+		}
+		line = line[i+1+len(sep):]
+	}
+}
+
+// recoverRecord recovers the CSV record that the underlying CSV reader failed
+// to parse with `err`, which started at offset `start`, if `err` is due to
+// malformed quotes and the WithQuoteRecovery option was given. Otherwise, it
+// returns `err`.
+func (r *Reader[T]) recoverRecord(start int64, err error) ([]string, error) {
+	var parseErr *csv.ParseError
+	if r.options.recoverySource == nil || !errors.As(err, &parseErr) || !errors.Is(parseErr.Err, csv.ErrBareQuote) && !errors.Is(parseErr.Err, csv.ErrQuote) {
+		return nil, err
+	}
+
+	if parseErr.StartLine != parseErr.Line {
+		// The record spans multiple lines, e.g., because of an unterminated
+		// quoted field, so it can't be split reliably.
+		return nil, err
+	}
+
+	data := make([]byte, r.reader.InputOffset()-start)
+	if n, readErr := r.options.recoverySource.ReadAt(data, r.baseOffset+start); n < len(data) {
+		return nil, fmt.Errorf("failed to recover from %w: %v", err, readErr)
+	}
+
+	row := splitRecord(string(bytes.TrimLeft(data, "\r\n")), r.reader.Comma)
+	if r.reader.FieldsPerRecord > 0 && len(row) != r.reader.FieldsPerRecord {
+		return nil, err
+	}
+
+	r.recoveredLine = r.baseLine + parseErr.StartLine
+	r.emit(Event{Kind: EventWarning, Line: r.recoveredLine, Err: err})
+	return row, nil
+}
+
+// WithQuoteRecovery recovers records with malformed quotes, e.g., stray quotes
+// in exports of real-world data, rather than failing.
+//
+// When the underlying CSV reader fails to parse a record because of a bare
+// quote in an unquoted field or an extraneous quote in a quoted field, the
+// record is read again from `src`, which must contain the same CSV data as the
+// underlying CSV reader, and it's split on a best-effort basis. The record is
+// then decoded as usual, and observers given with WithObserver are notified
+// with an EventWarning. Records that span multiple lines, or that have a
+// different number of fields than the CSV header, are not recovered.
+//
+// Errors in recovered records report the line of the record, but not the
+// position of the cell within the line.
+func WithQuoteRecovery(src io.ReaderAt) Option {
+	return func(o *options) {
+		o.recoverySource = src
+	}
+}
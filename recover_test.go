@@ -0,0 +1,86 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderQuoteRecovery(t *testing.T) {
+	const data = `Info.Name,Info.Class
+Alex,Fig"hter
+"Jay, den","Wiz"ard"
+
+Mary,Queen
+`
+
+	want := []Prefab{
+		{Info: &Info{"Alex", `Fig"hter`}},
+		{Info: &Info{"Jay, den", `Wiz"ard`}},
+		{Info: &Info{"Mary", "Queen"}},
+	}
+
+	var warnings []string
+	observer := func(event csvstruct.Event) {
+		if event.Kind == csvstruct.EventWarning {
+			warnings = append(warnings, fmt.Sprintf("line %d", event.Line))
+		}
+	}
+
+	source := strings.NewReader(data)
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(source), csvstruct.WithQuoteRecovery(source), csvstruct.WithObserver(observer))
+
+	for _, want := range want {
+		var got Prefab
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+
+	var got Prefab
+	if err := reader.Read(&got); err != io.EOF {
+		t.Fatalf("Read() err = %v; want %v", err, io.EOF)
+	}
+
+	if diff := cmp.Diff([]string{"line 2", "line 3"}, warnings); diff != "" {
+		t.Errorf("warnings diff = %v", diff)
+	}
+}
+
+func TestReaderQuoteRecovery_Errors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		// Without recovery.
+		{"Info.Name,Attributes.HP\nAlex,1\"0\n", "bare \" in non-quoted-field"},
+		// Recovered record with a decoding error.
+		{"Info.Name,Attributes.HP\nAlex,1\"0\n", `parsing "1\"0"`},
+		// Recovered record with the wrong number of fields.
+		{"Info.Name,Attributes.HP\nAl\"ex,1,0\n", "bare \" in non-quoted-field"},
+	}
+
+	for i, test := range tests {
+		source := strings.NewReader(test.data)
+
+		var opts []csvstruct.Option
+		if i > 0 {
+			opts = append(opts, csvstruct.WithQuoteRecovery(source))
+		}
+		reader := csvstruct.NewReader[Prefab](csv.NewReader(source), opts...)
+
+		var got Prefab
+		if err := reader.Read(&got); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Read() err = %v; want error containing %q", err, test.want)
+		}
+	}
+}
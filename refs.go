@@ -0,0 +1,156 @@
+package csvstruct
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// rowRef is a reference from a cell to another row of the same table, e.g.,
+// '@GoblinChief'.
+type rowRef struct {
+	// Line number of the cell containing the reference.
+	line int
+	// Column number of the cell containing the reference.
+	columnNum int
+	// Descriptor of the column containing the reference.
+	descriptor *colDescriptor
+	// Key of the referenced row, i.e., the cell without the leading '@'.
+	key string
+}
+
+// ReadLinked reads all the remaining rows of the current table and resolves
+// the references between them.
+//
+// Component fields of type `*T` are references to other rows of the same table.
+// The cells of those fields contain the key of the referenced row prefixed by
+// '@', e.g., '@GoblinChief'. The key of each row is computed by `keyFn`. After
+// all rows are read, each reference is resolved to a pointer to the referenced
+// row in the returned slice. If a component only contains references, it's
+// allocated when the references are resolved.
+//
+// Returns an error if two rows have the same key, if a reference points to a
+// key that doesn't exist, or if the references of the same field form a cycle,
+// e.g., a row that evolves into itself, either directly or through other rows.
+// References of different fields can form cycles, e.g., a row that summons
+// another row which evolves into the former.
+//
+// Read leaves fields of type `*T` set to nil since it can't resolve them.
+func (r *Reader[T]) ReadLinked(keyFn func(*T) string) ([]*T, error) {
+	var rows []*T
+	var refs [][]rowRef
+	for {
+		t := new(T)
+		err := r.Read(t)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, t)
+		refs = append(refs, append([]rowRef(nil), r.refs...))
+	}
+
+	index := make(map[string]int, len(rows))
+	for i, row := range rows {
+		key := keyFn(row)
+		if _, ok := index[key]; ok {
+			return nil, fmt.Errorf("duplicate row key %q", key)
+		}
+		index[key] = i
+	}
+
+	// Graph of references for each field, indexed by qualified column name.
+	graphs := map[string][][]int{}
+	var graphNames []string
+
+	for i, row := range rows {
+		value := reflect.ValueOf(row).Elem()
+
+		for _, ref := range refs[i] {
+			descriptor := ref.descriptor
+
+			target, ok := index[ref.key]
+			if !ok {
+				return nil, r.parseError(ref.line, ref.columnNum, descriptor, fmt.Errorf("reference to unknown row %q", ref.key))
+			}
+
+			name := descriptor.qualName()
+			edges, ok := graphs[name]
+			if !ok {
+				edges = make([][]int, len(rows))
+				graphNames = append(graphNames, name)
+			}
+			edges[i] = append(edges[i], target)
+			graphs[name] = edges
+
+			if err := descriptor.setField(value, rows[target]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, name := range graphNames {
+		if cycle := findCycle(graphs[name]); cycle != nil {
+			keys := make([]string, len(cycle))
+			for i, row := range cycle {
+				keys[i] = keyFn(rows[row])
+			}
+			return nil, fmt.Errorf("reference cycle in %s: %s", name, strings.Join(keys, " -> "))
+		}
+	}
+
+	return rows, nil
+}
+
+// findCycle returns a cycle in the graph given by the adjacency list `edges`,
+// or nil if the graph is acyclic. The first and last nodes of the returned
+// cycle are the same.
+func findCycle(edges [][]int) []int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(edges))
+	var path []int
+
+	var visit func(int) []int
+	visit = func(node int) []int {
+		state[node] = visiting
+		path = append(path, node)
+
+		for _, next := range edges[node] {
+			switch state[next] {
+			case visiting:
+				for i, n := range path {
+					if n == next {
+						return append(append([]int(nil), path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[node] = visited
+		return nil
+	}
+
+	for node := range edges {
+		if state[node] == unvisited {
+			if cycle := visit(node); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
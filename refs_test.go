@@ -0,0 +1,101 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/jabolopes/csvstruct"
+)
+
+type Monster struct {
+	Name string
+}
+
+type Evolution struct {
+	Next    *Creature
+	Summons *Creature
+}
+
+type Creature struct {
+	Monster   *Monster
+	Evolution *Evolution
+}
+
+func creatureKey(c *Creature) string { return c.Monster.Name }
+
+func TestReadLinked(t *testing.T) {
+	const data = `Monster.Name,Evolution.Next,Evolution.Summons
+Goblin,@Hobgoblin,
+Hobgoblin,@GoblinChief,
+GoblinChief,,@Goblin
+`
+
+	reader := csvstruct.NewReader[Creature](csv.NewReader(strings.NewReader(data)))
+
+	got, err := reader.ReadLinked(creatureKey)
+	if err != nil {
+		t.Fatalf("ReadLinked() err = %v; want %v", err, nil)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("ReadLinked() len = %d; want %d", len(got), 3)
+	}
+
+	goblin, hobgoblin, chief := got[0], got[1], got[2]
+	if goblin.Evolution.Next != hobgoblin {
+		t.Errorf("Goblin.Evolution.Next = %v; want %v", goblin.Evolution.Next, hobgoblin)
+	}
+	if goblin.Evolution.Summons != nil {
+		t.Errorf("Goblin.Evolution.Summons = %v; want %v", goblin.Evolution.Summons, nil)
+	}
+	if hobgoblin.Evolution.Next != chief {
+		t.Errorf("Hobgoblin.Evolution.Next = %v; want %v", hobgoblin.Evolution.Next, chief)
+	}
+	if chief.Evolution.Summons != goblin {
+		t.Errorf("GoblinChief.Evolution.Summons = %v; want %v", chief.Evolution.Summons, goblin)
+	}
+}
+
+func TestReadLinked_Errors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{
+			`Monster.Name,Evolution.Next
+Goblin,@Orc
+`,
+			`line 2, column 2 (Evolution.Next): reference to unknown row "Orc"`,
+		},
+		{
+			`Monster.Name,Evolution.Next
+Goblin,Hobgoblin
+Hobgoblin,
+`,
+			`expected row reference`,
+		},
+		{
+			`Monster.Name,Evolution.Next
+Goblin,@Hobgoblin
+Hobgoblin,@Goblin
+`,
+			`reference cycle in Evolution.Next: Goblin -> Hobgoblin -> Goblin`,
+		},
+		{
+			`Monster.Name,Evolution.Next
+Goblin,
+Goblin,
+`,
+			`duplicate row key "Goblin"`,
+		},
+	}
+
+	for _, test := range tests {
+		reader := csvstruct.NewReader[Creature](csv.NewReader(strings.NewReader(test.data)))
+
+		if _, err := reader.ReadLinked(creatureKey); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("ReadLinked() err = %v; want %q", err, test.want)
+		}
+	}
+}
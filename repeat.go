@@ -0,0 +1,85 @@
+package csvstruct
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// parseRepeatedName parses the component name of a column of a repeated column
+// group, e.g., 'Drops[1]', into the name of the slice field, e.g., 'Drops',
+// and the index of the element, e.g., 1. Returns false if `componentName` is
+// not indexed.
+func parseRepeatedName(componentName string) (string, int, bool, error) {
+	name, index, ok := strings.Cut(componentName, "[")
+	if !ok {
+		return componentName, 0, false, nil
+	}
+
+	index, ok = strings.CutSuffix(index, "]")
+	n, err := strconv.Atoi(index)
+	if !ok || err != nil || n < 0 {
+		return "", 0, false, fmt.Errorf("invalid component name %q; want an index, e.g., '%s[0]'", componentName, name)
+	}
+	return name, n, true, nil
+}
+
+// repeatedStruct returns the element type of the slice field of type `typ`,
+// if its elements are structs or pointers to structs, e.g., []Drop or
+// []*Drop.
+func repeatedStruct(typ reflect.Type) (reflect.Type, bool) {
+	if typ.Kind() != reflect.Slice {
+		return nil, false
+	}
+	return componentStruct(typ.Elem())
+}
+
+// allocRepeatedElem returns the element `index` of the slice `value`, growing
+// the slice with zero elements if it's shorter.
+func allocRepeatedElem(value reflect.Value, index int) reflect.Value {
+	if n := index + 1 - value.Len(); n > 0 {
+		value.Set(reflect.AppendSlice(value, reflect.MakeSlice(value.Type(), n, n)))
+	}
+	return value.Index(index)
+}
+
+// checkRepeatedIndices returns the errors of the columns of repeated column
+// groups whose indices are not contiguous, i.e., the indices of each slice
+// field must be 0, 1, 2, etc., without gaps, so that the length of the slices
+// is bounded by the width of the CSV header rather than by untrusted indices,
+// e.g., 'Drops[1000000000].Item'.
+func (r *Reader[T]) checkRepeatedIndices(row []string) []*ColumnError {
+	indices := map[string][]int{}
+	for _, descriptor := range r.colDescriptors {
+		if !descriptor.ignored && descriptor.isRepeated {
+			key := fmt.Sprint(descriptor.componentIndex)
+			indices[key] = append(indices[key], descriptor.repeatedIndex)
+		}
+	}
+
+	// Number of contiguous indices from 0 of each slice field.
+	contiguous := map[string]int{}
+	for key, sliceIndices := range indices {
+		slices.Sort(sliceIndices)
+		sliceIndices = slices.Compact(sliceIndices)
+		n := 0
+		for n < len(sliceIndices) && sliceIndices[n] == n {
+			n++
+		}
+		contiguous[key] = n
+	}
+
+	var errs []*ColumnError
+	for columnNum, descriptor := range r.colDescriptors {
+		if descriptor.ignored || !descriptor.isRepeated {
+			continue
+		}
+
+		if n := contiguous[fmt.Sprint(descriptor.componentIndex)]; descriptor.repeatedIndex >= n {
+			errs = append(errs, &ColumnError{Column: columnNum + 1, Name: row[columnNum], Err: fmt.Errorf("index %d of repeated component is not contiguous; want columns with index %d", descriptor.repeatedIndex, n)})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,83 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Spoils struct {
+	Drops []Drop
+	Bonus []*Drop
+}
+
+func TestReaderRepeated(t *testing.T) {
+	const data = `Drops[0].Item,Drops[0].Chance,Drops[1].Item,Drops[1].Chance,Bonus[0].Item
+Dagger,0.5,Coin,1,Gem
+Axe,0.25,,,
+,,Coin,1,
+`
+
+	reader := csvstruct.NewReader[Spoils](csv.NewReader(strings.NewReader(data)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Spoils{
+		{Drops: []Drop{{Item: "Dagger", Chance: 0.5}, {Item: "Coin", Chance: 1}}, Bonus: []*Drop{{Item: "Gem"}}},
+		{Drops: []Drop{{Item: "Axe", Chance: 0.25}}},
+		{Drops: []Drop{{}, {Item: "Coin", Chance: 1}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestReaderRepeated_InvalidHeader(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"Drops[x].Item", `invalid component name "Drops[x]"; want an index, e.g., 'Drops[0]'`},
+		{"Drops[-1].Item", `invalid component name "Drops[-1]"`},
+		{"Drops.Item", `field "Drops" of type csvstruct_test.Spoils is not a component`},
+		{"Drops[0].Weight", `does not have a field "Weight"`},
+		{"Drops[1].Item", `index 1 of repeated component is not contiguous; want columns with index 0`},
+		{"Drops[0].Item,Drops[1000000000].Item", `column 2 (Drops[1000000000].Item): index 1000000000 of repeated component is not contiguous; want columns with index 1`},
+		{"Drops[0].Item,Drops[2].Chance,Drops[3].Item", `column 3 (Drops[3].Item): index 3 of repeated component is not contiguous; want columns with index 1`},
+	}
+
+	for _, test := range tests {
+		reader := csvstruct.NewReader[Spoils](csv.NewReader(strings.NewReader(test.header + "\n")))
+
+		var got Spoils
+		if err := reader.Read(&got); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Read() err = %v; want %v", err, test.want)
+		}
+	}
+}
+
+func TestReaderRepeated_ParseError(t *testing.T) {
+	const data = `Drops[0].Item,Drops[1].Chance
+Dagger,often
+`
+
+	reader := csvstruct.NewReader[Spoils](csv.NewReader(strings.NewReader(data)))
+
+	var got Spoils
+	err := reader.Read(&got)
+
+	var parseErr *csvstruct.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Read() err = %v; want %T", err, parseErr)
+	}
+	if parseErr.Name != "Drops[1].Chance" || parseErr.Field != "Drops[1].Chance" {
+		t.Errorf("ParseError = %q, %q; want %q, %q", parseErr.Name, parseErr.Field, "Drops[1].Chance", "Drops[1].Chance")
+	}
+}
@@ -0,0 +1,41 @@
+package csvstruct
+
+import (
+	"io"
+	"math/rand/v2"
+)
+
+// Sample reads all the remaining rows of the current table and returns a
+// uniform random sample of `n` of them, e.g., so that data-quality tools can
+// inspect representative rows of huge files without keeping all of them in
+// memory. If the table has `n` rows or fewer, all of them are returned in
+// order. Otherwise, the order of the sample is unspecified.
+//
+// The sample is chosen with reservoir sampling, i.e., in a single pass over the
+// rows and keeping at most `n` rows in memory. If `rng` is nil, the top-level
+// random number generator is used.
+func Sample[T any](r *Reader[T], n int, rng *rand.Rand) ([]T, error) {
+	intN := rand.IntN
+	if rng != nil {
+		intN = rng.IntN
+	}
+
+	sample := make([]T, 0, max(n, 0))
+	for i := 0; ; i++ {
+		var t T
+		err := r.Read(&t)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if i < n {
+			sample = append(sample, t)
+		} else if j := intN(i + 1); j < n {
+			sample[j] = t
+		}
+	}
+	return sample, nil
+}
@@ -0,0 +1,58 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestSample(t *testing.T) {
+	var data strings.Builder
+	data.WriteString("Info.Name,Attributes.HP\n")
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&data, "Unit%d,%d\n", i, i)
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data.String())))
+	got, err := csvstruct.Sample(reader, 10, rand.New(rand.NewPCG(1, 2)))
+	if err != nil {
+		t.Fatalf("Sample() err = %v; want %v", err, nil)
+	}
+
+	if len(got) != 10 {
+		t.Fatalf("Sample() = %d rows; want %d", len(got), 10)
+	}
+	seen := map[int]bool{}
+	for _, prefab := range got {
+		hp := prefab.Attributes.HP
+		if want := fmt.Sprintf("Unit%d", hp); prefab.Info.Name != want || seen[hp] {
+			t.Errorf("Sample() row = %v; want distinct rows of the table", prefab)
+		}
+		seen[hp] = true
+	}
+	if !slices.ContainsFunc(got, func(p Prefab) bool { return p.Attributes.HP >= 10 }) {
+		t.Errorf("Sample() = %v; want rows beyond the first 10", got)
+	}
+}
+
+func TestSample_FewRows(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
+	got, err := csvstruct.Sample(reader, 10, nil)
+	if err != nil {
+		t.Fatalf("Sample() err = %v; want %v", err, nil)
+	}
+
+	want, err := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData))).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Sample() diff = %v", diff)
+	}
+}
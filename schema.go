@@ -0,0 +1,169 @@
+package csvstruct
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Schema describes the CSV header columns that a type accepts.
+type Schema struct {
+	// Qualified names of the columns, e.g., 'MyComponent.MyField', in the order
+	// of the fields of the type. Components without fields, e.g., marker
+	// components, have a single column, e.g., 'MyComponent'.
+	Columns []string
+}
+
+// SchemaFor returns the schema of the type `T`, i.e., the columns that a
+// Reader[T] accepts. Columns matched by patterns, e.g., `csv:"Stat_*"`, and
+// proto names are not included.
+func SchemaFor[T any]() Schema {
+	var schema Schema
+
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return schema
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		component := typ.Field(i)
+		componentType, ok := componentStruct(component.Type)
+		if !component.IsExported() || !ok {
+			continue
+		}
+
+		if componentType.NumField() == 0 {
+			schema.Columns = append(schema.Columns, columnName(component))
+			continue
+		}
+
+		for j := 0; j < componentType.NumField(); j++ {
+			if field := componentType.Field(j); field.IsExported() {
+				schema.Columns = append(schema.Columns, columnName(component)+"."+columnName(field))
+			}
+		}
+	}
+
+	return schema
+}
+
+// editDistance returns the Levenshtein distance between `a` and `b`, i.e., the
+// minimum number of single character insertions, deletions, and substitutions
+// that change `a` into `b`.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// SuggestColumns returns the columns of `schema` that are similar to the
+// column `unknown`, e.g., 'Info.Name' for 'Info.Nmae', ordered from the most
+// similar, so that validation tools can suggest fixes for typos in CSV
+// headers. Similarity is the case-insensitive edit distance, and columns that
+// differ in more than about a third of their characters are not suggested.
+func SuggestColumns(unknown string, schema Schema) []string {
+	type suggestion struct {
+		column   string
+		distance int
+	}
+
+	maxDistance := max(1, len([]rune(unknown))/3)
+
+	var suggestions []suggestion
+	for _, column := range schema.Columns {
+		distance := editDistance(strings.ToLower(unknown), strings.ToLower(column))
+		if distance <= maxDistance {
+			suggestions = append(suggestions, suggestion{column, distance})
+		}
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b suggestion) int {
+		return a.distance - b.distance
+	})
+
+	var columns []string
+	for _, suggestion := range suggestions {
+		columns = append(columns, suggestion.column)
+	}
+	return columns
+}
+
+// checkSchema checks that the schema of the type `T`, given as `schema`, is a
+// subset of the schema given with WithSchema, if any.
+func (o *options) checkSchema(schema Schema) error {
+	if o.schema == nil {
+		return nil
+	}
+
+	for _, column := range schema.Columns {
+		if !slices.Contains(o.schema.Columns, column) {
+			return fmt.Errorf("column %q is not in the schema given with WithSchema", column)
+		}
+	}
+	return nil
+}
+
+// isIgnoredColumn returns whether the CSV header column `column` is ignored
+// because it's not in `schema`, which is the schema of the type `T`, and
+// either it's in the schema given with WithSchema, the
+// WithIgnoreUnknownColumns option was given, or `T` has a field that captures
+// the unknown columns, as given by `hasUnknownField`.
+func (o *options) isIgnoredColumn(column string, schema Schema, hasUnknownField bool) bool {
+	qualName := o.mapping.qualName(column)
+	if slices.Contains(schema.Columns, qualName) {
+		return false
+	}
+	return o.ignoreUnknownColumns || hasUnknownField || o.schema != nil && slices.Contains(o.schema.Columns, qualName)
+}
+
+// ignoredDescriptor returns the descriptor of a column that is ignored.
+func ignoredDescriptor(qualName string) colDescriptor {
+	componentName, fieldName, _ := strings.Cut(qualName, ".")
+	return colDescriptor{componentName: componentName, fieldName: fieldName, ignored: true}
+}
+
+// WithSchema decodes CSV data authored for a larger type, whose schema is
+// `schema`, e.g., SchemaFor[Prefab](), into a smaller type `T` that contains a
+// subset of its components and fields, e.g., so that lightweight tools don't
+// need to import all the components.
+//
+// The columns that are in `schema` but not in `T` are ignored. Columns that
+// are in neither are still reported as errors, and Read returns an error if
+// `T` has columns that are not in `schema`, so that the smaller type is
+// verified against the full schema.
+func WithSchema(schema Schema) Option {
+	return func(o *options) {
+		o.schema = &schema
+	}
+}
+
+// effectiveSchema returns the schema of the current table, i.e., the columns
+// of its CSV header that are decoded, in the order of the CSV header.
+func (r *Reader[T]) effectiveSchema() Schema {
+	var schema Schema
+	for i := range r.colDescriptors {
+		if descriptor := &r.colDescriptors[i]; !descriptor.ignored {
+			schema.Columns = append(schema.Columns, descriptor.qualName())
+		}
+	}
+	return schema
+}
@@ -0,0 +1,117 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestSchemaFor(t *testing.T) {
+	want := csvstruct.Schema{Columns: []string{"Info.Name", "Info.Class", "Attributes.HP", "Attributes.Damage", "Player"}}
+
+	if diff := cmp.Diff(want, csvstruct.SchemaFor[Prefab]()); diff != "" {
+		t.Errorf("SchemaFor() diff = %v", diff)
+	}
+}
+
+func TestSuggestColumns(t *testing.T) {
+	schema := csvstruct.SchemaFor[Prefab]()
+
+	tests := []struct {
+		unknown string
+		want    []string
+	}{
+		{"Info.Nmae", []string{"Info.Name"}},
+		{"info.name", []string{"Info.Name"}},
+		{"Attributes.Hp", []string{"Attributes.HP"}},
+		{"Players", []string{"Player"}},
+		{"Inventory.Slots", nil},
+	}
+
+	for _, test := range tests {
+		got := csvstruct.SuggestColumns(test.unknown, schema)
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("SuggestColumns(%q) diff = %v", test.unknown, diff)
+		}
+	}
+}
+
+func TestReaderHeaderError_Suggestions(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader("Info.Nmae,Attributes.Damgae\nAlex,10\n")))
+
+	var got Prefab
+	err := reader.Read(&got)
+
+	var headerErr *csvstruct.HeaderError
+	if !errors.As(err, &headerErr) {
+		t.Fatalf("Read() err = %v; want %T", err, headerErr)
+	}
+
+	if want := "did you mean Info.Name?"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Read() err = %v; want error containing %q", err, want)
+	}
+
+	if diff := cmp.Diff([]string{"Attributes.Damage"}, headerErr.Columns[1].Suggestions); diff != "" {
+		t.Errorf("Suggestions diff = %v", diff)
+	}
+}
+
+type NameOnly struct {
+	Info *struct {
+		Name string
+	}
+}
+
+type NotInPrefab struct {
+	Info *struct {
+		Name  string
+		Title string
+	}
+}
+
+func TestWithSchema(t *testing.T) {
+	reader := csvstruct.NewReader[NameOnly](csv.NewReader(strings.NewReader(testData)), csvstruct.WithSchema(csvstruct.SchemaFor[Prefab]()))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	var names []string
+	for _, row := range got {
+		names = append(names, row.Info.Name)
+	}
+
+	want := []string{"Alex", "Jayden", "Mary", "Player"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestWithSchema_UnknownColumn(t *testing.T) {
+	const data = `Info.Name,Info.Title
+Alex,Sir
+`
+
+	reader := csvstruct.NewReader[NameOnly](csv.NewReader(strings.NewReader(data)), csvstruct.WithSchema(csvstruct.SchemaFor[Prefab]()))
+
+	var got NameOnly
+	var headerErr *csvstruct.HeaderError
+	if err := reader.Read(&got); !errors.As(err, &headerErr) {
+		t.Errorf("Read() err = %v; want %T", err, headerErr)
+	}
+}
+
+func TestWithSchema_NotSubset(t *testing.T) {
+	reader := csvstruct.NewReader[NotInPrefab](csv.NewReader(strings.NewReader(testData)), csvstruct.WithSchema(csvstruct.SchemaFor[Prefab]()))
+
+	var got NotInPrefab
+	const want = `column "Info.Title" is not in the schema given with WithSchema`
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
@@ -0,0 +1,142 @@
+package csvstruct
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"sync"
+)
+
+// shardRange is a byte range of CSV data that starts and ends at row
+// boundaries.
+type shardRange struct {
+	// Offset of the first byte of the shard.
+	start int64
+	// Offset of the byte after the last byte of the shard.
+	end int64
+	// Number of lines before the shard.
+	line int
+}
+
+// splitShards splits the CSV data in `ra` between offsets `start` and `size`
+// into at most `numShards` byte ranges of similar sizes that start and end at
+// row boundaries, i.e., newlines that are not within quoted fields.
+//
+// The data is scanned from the beginning to count lines and track quotes.
+func splitShards(ra io.ReaderAt, start, size int64, numShards int) ([]shardRange, error) {
+	shardSize := (size - start) / int64(numShards)
+
+	var shards []shardRange
+	current := shardRange{start: start}
+
+	reader := bufio.NewReader(io.NewSectionReader(ra, 0, size))
+	line := 0
+	quoted := false
+	for offset := int64(0); offset < size; offset++ {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch b {
+		case '"':
+			// Escaped quotes ("") toggle the state twice.
+			quoted = !quoted
+		case '\n':
+			line++
+			if quoted {
+				break
+			}
+
+			if offset+1 == start {
+				current.line = line
+			} else if offset+1 > start && offset+1-current.start >= shardSize && len(shards) < numShards-1 {
+				current.end = offset + 1
+				shards = append(shards, current)
+				current = shardRange{start: offset + 1, line: line}
+			}
+		}
+	}
+
+	current.end = size
+	if current.end > current.start {
+		shards = append(shards, current)
+	}
+	return shards, nil
+}
+
+// ReadAllSharded reads all the rows of the CSV data in `ra`, which has `size`
+// bytes and contains a single table, by splitting the data rows into
+// `numShards` byte ranges and parsing them concurrently. The CSV header is read
+// once and shared by all shards. The rows are returned in the same order as in
+// the CSV data.
+//
+// This scales decoding throughput with the number of cores for very large files
+// on fast storage. Splitting the data requires scanning it once for row
+// boundaries, which is much faster than decoding it.
+//
+// The underlying CSV readers are created by csv.NewReader and therefore they
+// use the default settings. If multiple shards fail, the error of the first
+// shard is returned. Observers given with WithObserver are called concurrently
+// by the shards, and therefore they must be thread-safe.
+func ReadAllSharded[T any](ra io.ReaderAt, size int64, numShards int, opts ...Option) ([]T, error) {
+	if numShards < 1 {
+		numShards = 1
+	}
+
+	headerReader := NewReader[T](csv.NewReader(io.NewSectionReader(ra, 0, size)), opts...)
+	if err := headerReader.readHeader(); err != nil {
+		return nil, err
+	}
+
+	ranges, err := splitShards(ra, headerReader.reader.InputOffset(), size, numShards)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]T, len(ranges))
+	errs := make([]error, len(ranges))
+
+	var wg sync.WaitGroup
+	for i, shard := range ranges {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			r, err := headerReader.Clone(csv.NewReader(io.NewSectionReader(ra, shard.start, shard.end-shard.start)))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			r.baseOffset = shard.start
+			r.baseLine = shard.line
+
+			for {
+				var t T
+				err := r.Read(&t)
+				if err == io.EOF {
+					return
+				}
+				if err != nil {
+					errs[i] = err
+					return
+				}
+
+				results[i] = append(results[i], t)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []T
+	for _, result := range results {
+		rows = append(rows, result...)
+	}
+	return rows, nil
+}
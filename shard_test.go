@@ -0,0 +1,56 @@
+package csvstruct_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReadAllSharded(t *testing.T) {
+	var data strings.Builder
+	data.WriteString("Info.Name,Info.Class,Attributes.HP\n")
+
+	var want []Prefab
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("Unit%d", i)
+		class := "Fighter"
+		if i%7 == 0 {
+			// Quoted fields with newlines must not be split.
+			class = "Multi\nline, \"quoted\""
+		}
+		fmt.Fprintf(&data, "%s,\"%s\",%d\n", name, strings.ReplaceAll(class, `"`, `""`), i)
+		want = append(want, Prefab{&Info{name, class}, &Attributes{HP: i}, nil})
+	}
+
+	for _, numShards := range []int{1, 3, 8, 1000} {
+		ra := strings.NewReader(data.String())
+
+		got, err := csvstruct.ReadAllSharded[Prefab](ra, ra.Size(), numShards)
+		if err != nil {
+			t.Fatalf("ReadAllSharded(%d) err = %v; want %v", numShards, err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("ReadAllSharded(%d) diff = %v", numShards, diff)
+		}
+	}
+}
+
+func TestReadAllSharded_Error(t *testing.T) {
+	const data = `Info.Name,Attributes.HP
+Alex,100
+Jayden,90
+Mary,many
+Player,1
+`
+
+	ra := strings.NewReader(data)
+
+	_, err := csvstruct.ReadAllSharded[Prefab](ra, ra.Size(), 3)
+	if err == nil || !strings.Contains(err.Error(), "invalid syntax") {
+		t.Fatalf("ReadAllSharded() err = %v; want invalid syntax error", err)
+	}
+}
@@ -0,0 +1,34 @@
+package csvstruct
+
+import (
+	"errors"
+	"io"
+)
+
+// skipInvalidRow returns whether the row that failed with `err` is skipped,
+// i.e., the WithSkipInvalidRows option was given and `err` is a problem of the
+// row, rather than the end of the table or a failure to read the CSV data. If
+// so, `err` is reported to the handler.
+func (r *Reader[T]) skipInvalidRow(err error) bool {
+	if err == nil || r.options.invalidRowHandler == nil || err == io.EOF || errors.Is(err, ErrEndOfSection) || r.readFailed {
+		return false
+	}
+
+	r.options.invalidRowHandler(err)
+	return true
+}
+
+// WithSkipInvalidRows skips the data rows that fail to be read or decoded,
+// e.g., malformed CSV records or cells that can't be parsed, rather than
+// failing the table, so that a single bad row in a file edited by hand doesn't
+// abort reading. The errors of the skipped rows, e.g., *ParseError, are given
+// to `handler`, e.g., to log them, and observers are notified with
+// EventRowSkipped.
+//
+// Errors of the CSV header and failures to read the CSV data, e.g., I/O
+// errors, still fail the table.
+func WithSkipInvalidRows(handler func(error)) Option {
+	return func(o *options) {
+		o.invalidRowHandler = handler
+	}
+}
@@ -0,0 +1,68 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderSkipInvalidRows(t *testing.T) {
+	const data = `Info.Name,Attributes.HP
+Alex,100
+Jayden,lots
+Mary,"bad"quote
+Zoe,70
+`
+
+	var errs []string
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithSkipInvalidRows(func(err error) {
+		errs = append(errs, err.Error())
+	}))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Prefab{
+		{Info: &Info{Name: "Alex"}, Attributes: &Attributes{HP: 100}},
+		{Info: &Info{Name: "Zoe"}, Attributes: &Attributes{HP: 70}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+
+	wantErrs := []string{
+		`line 3, column 2 (Attributes.HP): strconv.ParseInt: parsing "lots": invalid syntax`,
+		`parse error on line 4, column 10: extraneous or missing " in quoted-field`,
+	}
+	if diff := cmp.Diff(wantErrs, errs); diff != "" {
+		t.Errorf("WithSkipInvalidRows() errors diff = %v", diff)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestReaderSkipInvalidRows_ReadError(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(io.MultiReader(strings.NewReader("Info.Name\nAlex\n"), failingReader{})), csvstruct.WithSkipInvalidRows(func(err error) {
+		t.Errorf("WithSkipInvalidRows() handler called with %v; want no call", err)
+	}))
+
+	var got Prefab
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	if err := reader.Read(&got); err == nil || err.Error() != "connection reset" {
+		t.Errorf("Read() err = %v; want connection reset", err)
+	}
+}
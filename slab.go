@@ -0,0 +1,116 @@
+package csvstruct
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// slab is a contiguous block of structs of the same type, which are handed out
+// one at a time.
+type slab struct {
+	// Slice of structs.
+	elems reflect.Value
+	// Index of the next struct to hand out.
+	next int
+}
+
+// slabAllocator allocates component structs from slabs, one slab at a time per
+// component type. Only used with the WithSlabAllocation option.
+type slabAllocator struct {
+	// Number of structs per slab.
+	size int
+	// Current slab of each component type.
+	slabs map[reflect.Type]*slab
+	// All the slabs, in allocation order.
+	all []*slab
+}
+
+// alloc returns a pointer to a zero struct of type `typ`, allocating a new
+// slab if the current slab of `typ` is exhausted.
+func (a *slabAllocator) alloc(typ reflect.Type) reflect.Value {
+	s := a.slabs[typ]
+	if s == nil || s.next == s.elems.Len() {
+		s = &slab{elems: reflect.MakeSlice(reflect.SliceOf(typ), a.size, a.size)}
+		a.slabs[typ] = s
+		a.all = append(a.all, s)
+	}
+	elem := s.elems.Index(s.next).Addr()
+	s.next++
+	return elem
+}
+
+// allocComponent allocates the component `component`, which is a field of the
+// value of type `T` being decoded, from a slab if it's a nil pointer.
+func (a *slabAllocator) allocComponent(component reflect.Value) {
+	if component.Kind() == reflect.Pointer && component.IsNil() {
+		component.Set(a.alloc(component.Type().Elem()))
+	}
+}
+
+// allocDescriptorComponent allocates the component of the column described by
+// `descriptor` in `root`, which is the value of type `T` being decoded, from a
+// slab if it's a nil pointer, so that setField doesn't allocate it.
+func (a *slabAllocator) allocDescriptorComponent(root reflect.Value, descriptor *colDescriptor) {
+	component := allocFieldByIndex(root, descriptor.componentIndex)
+	if descriptor.isRepeated {
+		component = allocRepeatedElem(component, descriptor.repeatedIndex)
+	}
+	a.allocComponent(component)
+}
+
+// slabAllocator returns the allocator of the components of the decoded rows,
+// creating it if needed. Only used with the WithSlabAllocation option.
+func (r *Reader[T]) slabAllocator() *slabAllocator {
+	if r.slabs == nil {
+		r.slabs = &slabAllocator{size: r.options.slabSize, slabs: map[reflect.Type]*slab{}}
+	}
+	return r.slabs
+}
+
+// checkSlabSize returns an error if the size given with WithSlabAllocation is
+// negative.
+func (r *Reader[T]) checkSlabSize() error {
+	if r.options.slabSize < 0 {
+		return fmt.Errorf("invalid slab size %d; want a positive size, or 0 to disable slab allocation", r.options.slabSize)
+	}
+	return nil
+}
+
+// Slabs returns the slabs from which the components of the rows decoded so far
+// were allocated with the WithSlabAllocation option, in allocation order, e.g.,
+// so that the caller can keep them alongside the rows or account for their
+// memory. Each slab is a slice of structs of a component type, e.g., `[]Info`,
+// that contains only the structs handed out so far, i.e., the components of
+// the rows point into the slabs.
+//
+// Returns nil if the option was not given or if no component was allocated.
+func (r *Reader[T]) Slabs() []any {
+	if r.slabs == nil {
+		return nil
+	}
+
+	var slabs []any
+	for _, s := range r.slabs.all {
+		slabs = append(slabs, s.elems.Slice(0, s.next).Interface())
+	}
+	return slabs
+}
+
+// WithSlabAllocation allocates the components of type pointer to struct, e.g.,
+// `*Info`, from slabs of `size` structs per component type, rather than one
+// at a time, so that decoding large tables, e.g., with ReadAll, makes a handful
+// of large allocations instead of millions of small ones, which reduces the
+// impact of the garbage collector, e.g., during level loads.
+//
+// A slab is kept in memory while any of its components is referenced, so this
+// option should be used when the decoded rows are kept together, rather than
+// when most of them are discarded, e.g., after filtering. The slabs are
+// returned by Reader.Slabs.
+//
+// A size of 0 allocates components one at a time, as without this option.
+// Read returns an error if `size` is negative.
+func WithSlabAllocation(size int) Option {
+	return func(o *options) {
+		o.slabSize = size
+	}
+}
@@ -0,0 +1,92 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestWithSlabAllocation(t *testing.T) {
+	const data = `Info.Name,Info.Class,Player
+Alex,Fighter,1
+Jayden,Wizard,
+Mary,Queen,
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithSlabAllocation(2))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Prefab{
+		{Info: &Info{"Alex", "Fighter"}, Player: &Player{}},
+		{Info: &Info{"Jayden", "Wizard"}},
+		{Info: &Info{"Mary", "Queen"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+
+	// The first two rows share a slab, so their components are contiguous.
+	first := reflect.ValueOf(got[0].Info).Pointer()
+	second := reflect.ValueOf(got[1].Info).Pointer()
+	if size := reflect.TypeFor[Info]().Size(); second-first != size {
+		t.Errorf("second Info is %d bytes after the first; want %d", second-first, size)
+	}
+
+	// The rows point into the slabs returned by the reader.
+	wantSlabs := []any{
+		[]Info{{"Alex", "Fighter"}, {"Jayden", "Wizard"}},
+		[]Player{{}},
+		[]Info{{"Mary", "Queen"}},
+	}
+	if diff := cmp.Diff(wantSlabs, reader.Slabs()); diff != "" {
+		t.Errorf("Slabs() diff = %v", diff)
+	}
+	if slab := reader.Slabs()[0].([]Info); &slab[1] != got[1].Info {
+		t.Errorf("Slabs()[0][1] = %p; want %p", &slab[1], got[1].Info)
+	}
+}
+
+func TestWithSlabAllocation_NegativeSize(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader("Info.Name\nAlex\n")), csvstruct.WithSlabAllocation(-1))
+
+	var got Prefab
+	want := "invalid slab size -1; want a positive size, or 0 to disable slab allocation"
+	if err := reader.Read(&got); err == nil || err.Error() != want {
+		t.Errorf("Read() err = %v; want %v", err, want)
+	}
+}
+
+func TestReader_SlabsWithoutSlabAllocation(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader("Info.Name\nAlex\n")))
+	if _, err := reader.ReadAll(); err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	if got := reader.Slabs(); got != nil {
+		t.Errorf("Slabs() = %v; want %v", got, nil)
+	}
+}
+
+func BenchmarkReader_SlabAllocation(b *testing.B) {
+	var data strings.Builder
+	data.WriteString("Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player\n")
+	for i := 0; i < 1000; i++ {
+		data.WriteString("Alex,Fighter,100,10,\n")
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data.String())), csvstruct.WithSlabAllocation(1024))
+		if _, err := reader.ReadAll(); err != nil {
+			b.Fatalf("ReadAll() err = %v; want %v", err, nil)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package csvstruct
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// snapshotMagic identifies the snapshot format. The last byte is the version:
+// version 1 contains the rows, and version 2 contains the dictionaries of the
+// dictionary-encoded fields followed by the rows.
+var snapshotMagic = []byte("CSVSNP\x00\x02")
+
+// ErrSnapshotMismatch is returned by ReadSnapshot when the snapshot was written
+// for a different type.
+var ErrSnapshotMismatch = errors.New("snapshot was written for a different type")
+
+// typeFingerprint returns a hash of the description of the type `typ`, which
+// changes when the type or any of its fields change.
+func typeFingerprint(typ reflect.Type) []byte {
+	hash := sha256.New()
+	writeTypeFingerprint(hash, typ, map[reflect.Type]bool{})
+	return hash.Sum(nil)
+}
+
+// WriteSnapshot writes `rows` to `w` in a versioned binary format that
+// ReadSnapshot loads much faster than parsing CSV data, e.g., to ship
+// pre-baked tables in builds.
+//
+// The snapshot starts with a fingerprint of the type `T`, followed by the rows
+// encoded with encoding/gob. String fields with few distinct values, e.g.,
+// class names or rarities, are dictionary-encoded, i.e., each distinct value is
+// stored once and each row stores a small code instead, which significantly
+// shrinks the snapshots of large tables.
+func WriteSnapshot[T any](w io.Writer, rows []T) error {
+	dictionaries, rows := encodeDictionaries(rows)
+
+	writer := bufio.NewWriter(w)
+	writer.Write(snapshotMagic)
+	writer.Write(typeFingerprint(reflect.TypeFor[T]()))
+	encoder := gob.NewEncoder(writer)
+	if err := encoder.Encode(dictionaries); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := encoder.Encode(rows); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return writer.Flush()
+}
+
+// ReadSnapshot reads the rows of a snapshot written by WriteSnapshot.
+//
+// Returns ErrSnapshotMismatch if the snapshot was written for a different type,
+// e.g., because the type `T` changed since the snapshot was written, in which
+// case the snapshot must be written again from the CSV data.
+func ReadSnapshot[T any](r io.Reader) ([]T, error) {
+	reader := bufio.NewReader(r)
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	prefix := snapshotMagic[:len(snapshotMagic)-1]
+	version := magic[len(magic)-1]
+	if !bytes.HasPrefix(magic, prefix) || version < 1 || version > snapshotMagic[len(snapshotMagic)-1] {
+		return nil, errors.New("failed to read snapshot: unknown format or version")
+	}
+
+	fingerprint := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(reader, fingerprint); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if !bytes.Equal(fingerprint, typeFingerprint(reflect.TypeFor[T]())) {
+		return nil, ErrSnapshotMismatch
+	}
+
+	decoder := gob.NewDecoder(reader)
+	var dictionaries []snapshotDictionary
+	if version >= 2 {
+		if err := decoder.Decode(&dictionaries); err != nil {
+			return nil, fmt.Errorf("failed to read snapshot: %w", err)
+		}
+	}
+
+	var rows []T
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := decodeDictionaries(dictionaries, rows); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,98 @@
+package csvstruct_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestSnapshot(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := csvstruct.WriteSnapshot(&buffer, testPrefabs); err != nil {
+		t.Fatalf("WriteSnapshot() err = %v; want %v", err, nil)
+	}
+
+	got, err := csvstruct.ReadSnapshot[Prefab](&buffer)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(testPrefabs, got); diff != "" {
+		t.Errorf("ReadSnapshot() diff = %v", diff)
+	}
+}
+
+func TestSnapshot_Mismatch(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := csvstruct.WriteSnapshot(&buffer, testPrefabs); err != nil {
+		t.Fatalf("WriteSnapshot() err = %v; want %v", err, nil)
+	}
+
+	if _, err := csvstruct.ReadSnapshot[Item](&buffer); !errors.Is(err, csvstruct.ErrSnapshotMismatch) {
+		t.Errorf("ReadSnapshot() err = %v; want %v", err, csvstruct.ErrSnapshotMismatch)
+	}
+}
+
+func TestSnapshot_InvalidFormat(t *testing.T) {
+	if _, err := csvstruct.ReadSnapshot[Prefab](bytes.NewReader([]byte("Info.Name\nAlex\n"))); err == nil {
+		t.Errorf("ReadSnapshot() err = %v; want error", err)
+	}
+}
+
+func TestSnapshot_Dictionary(t *testing.T) {
+	newPrefabs := func() []Prefab {
+		classes := []string{"Fighter", "Wizard", "Rogue"}
+		var prefabs []Prefab
+		for i := 0; i < 1000; i++ {
+			info := &Info{fmt.Sprintf("Unit%d", i), classes[i%len(classes)]}
+			prefabs = append(prefabs, Prefab{Info: info, Attributes: &Attributes{i, i % 7}})
+		}
+		return append(prefabs, Prefab{Info: &Info{}}, Prefab{})
+	}
+	prefabs := newPrefabs()
+	want := newPrefabs()
+
+	var buffer bytes.Buffer
+	if err := csvstruct.WriteSnapshot(&buffer, prefabs); err != nil {
+		t.Fatalf("WriteSnapshot() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(want, prefabs); diff != "" {
+		t.Fatalf("WriteSnapshot() modified rows diff = %v", diff)
+	}
+
+	var plain bytes.Buffer
+	if err := gob.NewEncoder(&plain).Encode(prefabs); err != nil {
+		t.Fatalf("Encode() err = %v; want %v", err, nil)
+	}
+	if buffer.Len() >= plain.Len() {
+		t.Errorf("WriteSnapshot() size = %d; want less than %d", buffer.Len(), plain.Len())
+	}
+
+	got, err := csvstruct.ReadSnapshot[Prefab](&buffer)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() err = %v; want %v", err, nil)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadSnapshot() diff = %v", diff)
+	}
+}
+
+func TestSnapshot_Empty(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := csvstruct.WriteSnapshot[Prefab](&buffer, nil); err != nil {
+		t.Fatalf("WriteSnapshot() err = %v; want %v", err, nil)
+	}
+
+	got, err := csvstruct.ReadSnapshot[Prefab](&buffer)
+	if err != nil {
+		t.Fatalf("ReadSnapshot() err = %v; want %v", err, nil)
+	}
+	if len(got) != 0 {
+		t.Errorf("ReadSnapshot() = %v; want no rows", got)
+	}
+}
@@ -0,0 +1,227 @@
+package csvstruct
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Placeholders is the style of the placeholders of the arguments of SQL
+// statements, which depends on the database.
+type Placeholders int
+
+const (
+	// QuestionPlaceholders are '?', e.g., for SQLite and MySQL.
+	QuestionPlaceholders Placeholders = iota
+	// DollarPlaceholders are '$1', '$2', etc., e.g., for PostgreSQL.
+	DollarPlaceholders
+)
+
+// InsertBatch is a parameterized SQL INSERT statement and its arguments, e.g.,
+// for database/sql's DB.Exec.
+type InsertBatch struct {
+	Query string
+	Args  []any
+}
+
+// quoteIdentifier quotes a SQL identifier, e.g., a table or column name.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// sqlColumns returns the quoted and comma-separated column names.
+func (e *encoder[T]) sqlColumns() string {
+	columns := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		columns[i] = quoteIdentifier(column.qualName)
+	}
+	return strings.Join(columns, ", ")
+}
+
+// sqlValues converts `t` to SQL values, one per column. Nil components are NULL,
+// i.e., nil, marker components are 1 when present, and numbers and strings keep
+// their types. Other fields, e.g., fields with codecs, are strings.
+func (e *encoder[T]) sqlValues(t T) ([]any, error) {
+	record, err := e.encode(t)
+	if err != nil {
+		return nil, err
+	}
+
+	value := reflect.ValueOf(t)
+	values := make([]any, len(e.columns))
+	for i, column := range e.columns {
+		if column.isUnknown() {
+			continue
+		}
+
+		component := componentValue(value.Field(column.componentIndex))
+		if !component.IsValid() {
+			continue
+		}
+
+		if column.fieldIndex < 0 {
+			values[i] = int64(1)
+			continue
+		}
+
+		field := component.Field(column.fieldIndex)
+		switch {
+		case column.codec != nil || column.isUnion || column.isRedacted:
+			values[i] = record[i]
+		case field.CanInt():
+			values[i] = field.Int()
+		case field.CanUint():
+			values[i] = field.Uint()
+		case field.CanFloat():
+			values[i] = field.Float()
+		default:
+			values[i] = record[i]
+		}
+	}
+	return values, nil
+}
+
+// sqlType returns the SQL type of the column, which is INTEGER, REAL, or TEXT.
+func (e *encoder[T]) sqlType(column writeColumn) string {
+	if column.isUnknown() {
+		return "TEXT"
+	}
+	if column.fieldIndex < 0 {
+		return "INTEGER"
+	}
+
+	componentType, _ := componentStruct(reflect.TypeFor[T]().Field(column.componentIndex).Type)
+	field := componentType.Field(column.fieldIndex)
+	if column.codec != nil || column.isUnion || column.isRedacted {
+		return "TEXT"
+	}
+
+	switch field.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "INTEGER"
+	case reflect.Float32, reflect.Float64:
+		return "REAL"
+	}
+	return "TEXT"
+}
+
+// CreateTable returns a SQL CREATE TABLE statement for the table `table`, if it
+// doesn't exist, whose columns are the ones inserted by InsertBatches with the
+// same options. The column types are INTEGER, REAL, or TEXT.
+//
+// Returns an error if the type `T` is not a struct or if the options select
+// components or fields that `T` doesn't have.
+func CreateTable[T any](table string, opts ...WriterOption) (string, error) {
+	e := newEncoder[T](opts)
+	if e.err != nil {
+		return "", e.err
+	}
+
+	columns := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		columns[i] = quoteIdentifier(column.qualName) + " " + e.sqlType(column)
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", quoteIdentifier(table), strings.Join(columns, ", ")), nil
+}
+
+// InsertBatches converts `rows` to parameterized SQL INSERT statements into
+// `table`, each with at most `batchSize` rows, e.g., to load data into
+// databases for analysis. The columns are the qualified names of the columns
+// written by Writer, e.g., "Info.Name", and they can be selected with the same
+// options, e.g., WithWriteComponents.
+//
+// Nil components are inserted as NULL.
+func InsertBatches[T any](table string, rows []T, batchSize int, placeholders Placeholders, opts ...WriterOption) ([]InsertBatch, error) {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	e := newEncoder[T](opts)
+	if e.err != nil {
+		return nil, e.err
+	}
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quoteIdentifier(table), e.sqlColumns())
+
+	var batches []InsertBatch
+	for start := 0; start < len(rows); start += batchSize {
+		end := min(start+batchSize, len(rows))
+
+		var query strings.Builder
+		query.WriteString(prefix)
+
+		var args []any
+		for i, row := range rows[start:end] {
+			values, err := e.sqlValues(row)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", start+i, err)
+			}
+
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			query.WriteString("(")
+			for j, value := range values {
+				if j > 0 {
+					query.WriteString(", ")
+				}
+				if placeholders == DollarPlaceholders {
+					query.WriteString("$" + strconv.Itoa(len(args)+1))
+				} else {
+					query.WriteString("?")
+				}
+				args = append(args, value)
+			}
+			query.WriteString(")")
+		}
+
+		batches = append(batches, InsertBatch{query.String(), args})
+	}
+
+	return batches, nil
+}
+
+// copyReplacer escapes the characters that are special in the text format of
+// PostgreSQL's COPY.
+var copyReplacer = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r")
+
+// WriteCopy writes `rows` to `w` as a PostgreSQL COPY statement into `table`
+// followed by the data in text format, e.g., to be run by psql. The columns are
+// the same as in InsertBatches.
+//
+// Nil components are written as NULL, i.e., '\N'.
+func WriteCopy[T any](w io.Writer, table string, rows []T, opts ...WriterOption) error {
+	e := newEncoder[T](opts)
+	if e.err != nil {
+		return e.err
+	}
+
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "COPY %s (%s) FROM stdin;\n", quoteIdentifier(table), e.sqlColumns())
+	for i, row := range rows {
+		values, err := e.sqlValues(row)
+		if err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+
+		for j, value := range values {
+			if j > 0 {
+				writer.WriteString("\t")
+			}
+
+			switch value := value.(type) {
+			case nil:
+				writer.WriteString(`\N`)
+			case string:
+				copyReplacer.WriteString(writer, value)
+			default:
+				fmt.Fprint(writer, value)
+			}
+		}
+		writer.WriteString("\n")
+	}
+	writer.WriteString("\\.\n")
+	return writer.Flush()
+}
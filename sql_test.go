@@ -0,0 +1,83 @@
+package csvstruct_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestInsertBatches(t *testing.T) {
+	got, err := csvstruct.InsertBatches("prefabs", testPrefabs, 3, csvstruct.QuestionPlaceholders, csvstruct.WithWriteComponents("Info.Name", "Attributes.HP", "Player"))
+	if err != nil {
+		t.Fatalf("InsertBatches() err = %v; want %v", err, nil)
+	}
+
+	want := []csvstruct.InsertBatch{
+		{
+			`INSERT INTO "prefabs" ("Info.Name", "Attributes.HP", "Player") VALUES (?, ?, ?), (?, ?, ?), (?, ?, ?)`,
+			[]any{"Alex", int64(100), nil, "Jayden", int64(90), nil, "Mary", nil, nil},
+		},
+		{
+			`INSERT INTO "prefabs" ("Info.Name", "Attributes.HP", "Player") VALUES (?, ?, ?)`,
+			[]any{"Player", nil, int64(1)},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("InsertBatches() diff = %v", diff)
+	}
+}
+
+func TestInsertBatches_Dollar(t *testing.T) {
+	got, err := csvstruct.InsertBatches("prefabs", testPrefabs[:2], 2, csvstruct.DollarPlaceholders, csvstruct.WithWriteComponents("Info.Name"))
+	if err != nil {
+		t.Fatalf("InsertBatches() err = %v; want %v", err, nil)
+	}
+
+	want := `INSERT INTO "prefabs" ("Info.Name") VALUES ($1), ($2)`
+	if len(got) != 1 || got[0].Query != want {
+		t.Errorf("InsertBatches() = %v; want query %q", got, want)
+	}
+}
+
+func TestWriteCopy(t *testing.T) {
+	items := []Item{
+		{&Weapon{"Sword", csvstruct.Dice{1, 8, 1}}},
+		{&Weapon{"Tab\tSword", csvstruct.Dice{1, 4, 0}}},
+		{nil},
+	}
+
+	var got strings.Builder
+	if err := csvstruct.WriteCopy(&got, "items", items); err != nil {
+		t.Fatalf("WriteCopy() err = %v; want %v", err, nil)
+	}
+
+	want := `COPY "items" ("Weapon.Name", "Weapon.Damage") FROM stdin;
+Sword	1d8+1
+Tab\tSword	1d4
+\N	\N
+\.
+`
+
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("WriteCopy() diff = %v", diff)
+	}
+}
+
+func TestCreateTable(t *testing.T) {
+	got, err := csvstruct.CreateTable[Prefab]("prefabs")
+	if err != nil {
+		t.Fatalf("CreateTable() err = %v; want %v", err, nil)
+	}
+
+	want := `CREATE TABLE IF NOT EXISTS "prefabs" ("Info.Name" TEXT, "Info.Class" TEXT, "Attributes.HP" INTEGER, "Attributes.Damage" INTEGER, "Player" INTEGER)`
+	if got != want {
+		t.Errorf("CreateTable() = %q; want %q", got, want)
+	}
+
+	if _, err := csvstruct.CreateTable[Prefab]("prefabs", csvstruct.WithWriteComponents("Inventory")); err == nil {
+		t.Errorf("CreateTable() err = %v; want error", err)
+	}
+}
@@ -0,0 +1,65 @@
+package csvstruct
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// TableStats summarizes the decoding of a table, e.g., for import reports shown
+// to content authors.
+type TableStats struct {
+	// Name of the section of the table. Only used with the WithSections option.
+	Section string
+	// Number of data rows that were decoded successfully.
+	RowsDecoded int
+	// Number of rows that were skipped, e.g., by middleware.
+	RowsSkipped int
+	// Number of warnings, e.g., recovered records with malformed quotes.
+	Warnings int
+	// Number of empty cells of each column in the decoded rows, indexed by
+	// qualified name, e.g., 'MyComponent.MyField'. Skipped rows are not
+	// counted.
+	EmptyCells map[string]int
+	// Total time that it took to decode the decoded rows. Only used with the
+	// WithRowTiming option.
+	DecodeTime time.Duration
+	// Rows whose decoding took at least the threshold given with WithRowTiming,
+	// in the order in which they were read.
+	SlowRows []SlowRow
+}
+
+// record updates the statistics with the given event.
+func (s *TableStats) record(event Event) {
+	switch event.Kind {
+	case EventTableStarted:
+		*s = TableStats{Section: event.Section, EmptyCells: map[string]int{}}
+	case EventRowDecoded:
+		s.RowsDecoded++
+		s.DecodeTime += event.Duration
+	case EventRowSkipped:
+		s.RowsSkipped++
+	case EventWarning:
+		s.Warnings++
+	}
+}
+
+// countEmptyCells counts the empty cells of the current row in the statistics,
+// once the row is decoded, so that skipped rows are not counted.
+func (r *Reader[T]) countEmptyCells() {
+	if r.stats.EmptyCells == nil {
+		return
+	}
+	for _, column := range r.emptyColumns {
+		r.stats.EmptyCells[column]++
+	}
+}
+
+// Stats returns the statistics of the current table, or of the most recent
+// table after it ends, e.g., after Read returns io.EOF or ErrEndOfSection.
+func (r *Reader[T]) Stats() TableStats {
+	stats := r.stats
+	stats.EmptyCells = maps.Clone(r.stats.EmptyCells)
+	stats.SlowRows = slices.Clone(r.stats.SlowRows)
+	return stats
+}
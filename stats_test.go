@@ -0,0 +1,66 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderStats(t *testing.T) {
+	const data = `[Heroes]
+Info.Name,Info.Class
+Alex,Fighter
+Jayden,
+draft,
+ghost,
+[Villains]
+Info.Name,Info.Class
+Mary,Queen
+`
+
+	skipDrafts := func(next csvstruct.RowFunc) csvstruct.RowFunc {
+		return func(row *csvstruct.Row) error {
+			if row.Cells[0] == "draft" {
+				return csvstruct.ErrSkipRow
+			}
+			// Rows skipped after they are decoded don't count their empty cells.
+			if err := next(row); err != nil || row.Cells[0] != "ghost" {
+				return err
+			}
+			return csvstruct.ErrSkipRow
+		}
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithSections(), csvstruct.WithMiddleware(skipDrafts))
+
+	var got []csvstruct.TableStats
+	for {
+		var prefab Prefab
+		err := reader.Read(&prefab)
+		if err == io.EOF {
+			got = append(got, reader.Stats())
+			break
+		}
+		if err == csvstruct.ErrEndOfSection {
+			got = append(got, reader.Stats())
+			reader.Clear()
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+	}
+
+	want := []csvstruct.TableStats{
+		{Section: "Heroes", RowsDecoded: 2, RowsSkipped: 2, EmptyCells: map[string]int{"Info.Class": 1}},
+		{Section: "Villains", RowsDecoded: 1, EmptyCells: map[string]int{}},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Stats() diff = %v", diff)
+	}
+}
@@ -0,0 +1,40 @@
+package csvstruct
+
+import (
+	"context"
+	"io"
+)
+
+// ReadToChan reads all the remaining rows of the current table and sends them
+// to `ch`, in the same order as in the CSV data. Since sends block until the
+// rows are received, reading doesn't get ahead of the receivers, e.g., a pool
+// of workers.
+//
+// Returns nil at the end of the table, the first read error, or the context's
+// error if `ctx` is done before all the rows are sent, including while waiting
+// for the rate limiters given with WithRowRateLimit and WithByteRateLimit.
+//
+// The channel is not closed, so that the caller can send the rows of multiple
+// tables to the same channel, and close it when it's done.
+func (r *Reader[T]) ReadToChan(ctx context.Context, ch chan<- T) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var t T
+		err := r.read(ctx, &t)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		select {
+		case ch <- t:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
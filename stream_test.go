@@ -0,0 +1,53 @@
+package csvstruct_test
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestReaderReadToChan(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
+
+	ch := make(chan Prefab)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- reader.ReadToChan(context.Background(), ch)
+		close(ch)
+	}()
+
+	var got []Prefab
+	for prefab := range ch {
+		got = append(got, prefab)
+	}
+
+	if err := <-errc; err != nil {
+		t.Fatalf("ReadToChan() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(testPrefabs, got); diff != "" {
+		t.Errorf("ReadToChan() diff = %v", diff)
+	}
+}
+
+func TestReaderReadToChan_Cancel(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(testData)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan Prefab)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- reader.ReadToChan(ctx, ch)
+	}()
+
+	<-ch
+	cancel()
+
+	if err := <-errc; err != context.Canceled {
+		t.Errorf("ReadToChan() err = %v; want %v", err, context.Canceled)
+	}
+}
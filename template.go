@@ -0,0 +1,94 @@
+package csvstruct
+
+import (
+	"encoding/csv"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// templateTime is the time of the placeholders of time.Time fields.
+var templateTime = time.Date(2024, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+// placeholder returns the placeholder cell of the column `column` of the
+// type `T` in the example row `row`, starting at 0. The placeholder is the
+// default value of the field, if any, or the first value of its enum, if any,
+// or otherwise an example value derived from the field's type, e.g., 'Name1'
+// for a string field 'Name' in the first row.
+func placeholder[T any](column *writeColumn, row int) string {
+	if column.isUnknown() || column.isUnion {
+		return ""
+	}
+	if column.fieldIndex < 0 {
+		return "1"
+	}
+
+	componentType, _ := componentStruct(reflect.TypeFor[T]().Field(column.componentIndex).Type)
+	field := componentType.Field(column.fieldIndex)
+	if value, ok := columnOptionValue(field, "default"); ok {
+		return value
+	}
+	if enum, ok := columnOptionValue(field, "enum"); ok {
+		first, _, _ := strings.Cut(enum, "|")
+		return first
+	}
+
+	switch {
+	case column.codec != nil:
+		cell, err := column.codec.Encode(reflect.New(field.Type).Elem())
+		if err != nil {
+			return ""
+		}
+		return cell
+	case len(column.timeLayout) > 0:
+		return templateTime.AddDate(0, 0, row).Format(column.timeLayout)
+	case field.Type == durationType:
+		return (time.Duration(row+1) * time.Second).String()
+	}
+
+	switch field.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.Itoa(row + 1)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(float64(row)+1.5, 'g', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(row%2 == 0)
+	case reflect.String:
+		return field.Name + strconv.Itoa(row+1)
+	}
+	return ""
+}
+
+// WriteTemplate writes a template of CSV data for the type `T` to `w`, i.e.,
+// the CSV header that Writer writes followed by `exampleRows` rows of
+// placeholders, e.g., so that designers get a correctly shaped spreadsheet to
+// start a new table.
+//
+// The placeholder of a field is the value of the `default` option of its
+// `csvstruct` tag, if any, or the first value of its `enum` option, if any, or
+// otherwise an example value derived from its type, e.g., 'Name1', 'Name2',
+// etc., for a string field 'Name', '1', '2', etc., for an int field, or the
+// zero value for a field with a codec. Marker components are present and union
+// fields are empty.
+//
+// The writer can be configured with options, e.g., WithWriteComponents to
+// write a template of a subset of the columns.
+func WriteTemplate[T any](w io.Writer, exampleRows int, opts ...WriterOption) error {
+	writer := NewWriter[T](csv.NewWriter(w), opts...)
+	if err := writer.WriteHeader(); err != nil {
+		return err
+	}
+
+	record := make([]string, len(writer.columns))
+	for row := 0; row < exampleRows; row++ {
+		for i := range writer.columns {
+			record[i] = placeholder[T](&writer.columns[i], row)
+		}
+		if err := writer.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,83 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestWriteTemplate(t *testing.T) {
+	var buf strings.Builder
+	if err := csvstruct.WriteTemplate[Prefab](&buf, 2); err != nil {
+		t.Fatalf("WriteTemplate() err = %v; want %v", err, nil)
+	}
+
+	want := `Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player
+Name1,Class1,1,1,1
+Name2,Class2,2,2,1
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteTemplate() diff = %v", diff)
+	}
+}
+
+func TestWriteTemplate_Tags(t *testing.T) {
+	var buf strings.Builder
+	if err := csvstruct.WriteTemplate[Tuned](&buf, 1, csvstruct.WithWriteComponents("Balance")); err != nil {
+		t.Fatalf("WriteTemplate() err = %v; want %v", err, nil)
+	}
+
+	want := `Balance.HP,Balance.speed,Balance.Cooldown,Balance.Rarity
+100,1.5,2s,common
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteTemplate() diff = %v", diff)
+	}
+}
+
+func TestWriteTemplate_Codec(t *testing.T) {
+	var buf strings.Builder
+	if err := csvstruct.WriteTemplate[Item](&buf, 1); err != nil {
+		t.Fatalf("WriteTemplate() err = %v; want %v", err, nil)
+	}
+
+	want := `Weapon.Name,Weapon.Damage
+Name1,0
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteTemplate() diff = %v", diff)
+	}
+}
+
+type Rune struct {
+	School string `csvstruct:",enum=fire|ice"`
+	Power  int
+}
+
+type Scroll struct {
+	Rune *Rune
+}
+
+func TestWriteTemplate_Enum(t *testing.T) {
+	var buf strings.Builder
+	if err := csvstruct.WriteTemplate[Scroll](&buf, 2); err != nil {
+		t.Fatalf("WriteTemplate() err = %v; want %v", err, nil)
+	}
+
+	want := `Rune.School,Rune.Power
+fire,1
+fire,2
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteTemplate() diff = %v", diff)
+	}
+
+	// The template is a valid table.
+	reader := csvstruct.NewReader[Scroll](csv.NewReader(strings.NewReader(buf.String())))
+	if _, err := reader.ReadAll(); err != nil {
+		t.Errorf("ReadAll() err = %v; want %v", err, nil)
+	}
+}
@@ -0,0 +1,89 @@
+package csvstruct
+
+import (
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// textCellReplacer replaces the characters that would break the alignment of a
+// text table.
+var textCellReplacer = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+// TextWriter writes component data as a plain text table whose columns are
+// aligned with spaces, for human-readable dumps, e.g., in logs and terminals.
+// The first row contains the qualified names of the columns, like the CSV header
+// written by Writer. The output is not meant to be parsed.
+//
+// Columns are aligned with elastic tabstops, i.e., the rows are buffered until
+// Flush is called, so that the width of each column fits all of its cells.
+//
+// This is thread compatible, i.e., it's safe for non-concurrent use and it can
+// be combined with external synchronization so it can be called concurrently.
+type TextWriter[T any] struct {
+	encoder[T]
+	// Underlying writer that aligns the columns.
+	writer *tabwriter.Writer
+	// Whether the header has been written.
+	hasHeader bool
+}
+
+// writeRow writes a row of cells, separated by tabs.
+func (w *TextWriter[T]) writeRow(cells []string) error {
+	for i, cell := range cells {
+		if i > 0 {
+			if _, err := io.WriteString(w.writer, "\t"); err != nil {
+				return err
+			}
+		}
+		if _, err := textCellReplacer.WriteString(w.writer, cell); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w.writer, "\n")
+	return err
+}
+
+// Write writes `t` as a row of the table. Before the first row, the header is
+// written.
+//
+// Rows are buffered, so Flush must be called to ensure that the table is
+// written to the underlying io.Writer.
+func (w *TextWriter[T]) Write(t T) error {
+	if w.err != nil {
+		return w.err
+	}
+	if !w.hasHeader {
+		if err := w.writeRow(w.header()); err != nil {
+			return err
+		}
+		w.hasHeader = true
+	}
+
+	record, err := w.encode(t)
+	if err != nil {
+		return err
+	}
+	return w.writeRow(record)
+}
+
+// Flush aligns the buffered rows and writes them to the underlying io.Writer.
+// Rows written after Flush are aligned separately.
+func (w *TextWriter[T]) Flush() error {
+	return w.writer.Flush()
+}
+
+// NewTextWriter returns a new writer that writes a text table to `writer`. The
+// type `T` is the schema that is used to write the data.
+//
+// The writer can be configured with the same options as Writer, e.g.,
+// WithWriteComponents.
+//
+// If the type `T` is not a struct or if the options select components or
+// fields that `T` doesn't have, the first Write returns the error.
+func NewTextWriter[T any](writer io.Writer, opts ...WriterOption) *TextWriter[T] {
+	return &TextWriter[T]{
+		encoder: newEncoder[T](opts),
+		writer:  tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0),
+	}
+}
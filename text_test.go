@@ -0,0 +1,50 @@
+package csvstruct_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestTextWriter(t *testing.T) {
+	var got strings.Builder
+	writer := csvstruct.NewTextWriter[Prefab](&got)
+	for _, prefab := range testPrefabs {
+		if err := writer.Write(prefab); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want := "Info.Name  Info.Class  Attributes.HP  Attributes.Damage  Player\n" +
+		"Alex       Fighter     100            10                 \n" +
+		"Jayden     Wizard      90             20                 \n" +
+		"Mary       Queen                                         \n" +
+		"Player                                                   1\n"
+
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+}
+
+func TestTextWriter_Escape(t *testing.T) {
+	var got strings.Builder
+	writer := csvstruct.NewTextWriter[Prefab](&got, csvstruct.WithWriteComponents("Info"))
+	if err := writer.Write(Prefab{Info: &Info{"Alex\tJr", "Fighter\nMage"}}); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want := "Info.Name  Info.Class\n" +
+		"Alex Jr    Fighter Mage\n"
+
+	if diff := cmp.Diff(want, got.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+}
@@ -0,0 +1,37 @@
+package csvstruct
+
+import (
+	"reflect"
+	"time"
+)
+
+var (
+	// Type of time.Time fields, which are parsed with a layout.
+	timeType = reflect.TypeFor[time.Time]()
+	// Type of time.Duration fields, which are parsed with time.ParseDuration.
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+// formatTime formats the value of a time.Time field with the given layout.
+// The zero time is formatted as an empty cell, which Reader parses back as the
+// zero time.
+func formatTime(value reflect.Value, layout string) string {
+	t := value.Interface().(time.Time)
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+// WithTimeLayout sets the layout, e.g., '2006-01-02', of the cells of time.Time
+// fields, which is time.RFC3339 by default. See time.Parse for the format of
+// layouts.
+//
+// The layout of a single field can be set with its `csvstruct` tag, which
+// takes precedence over this option, e.g., `csvstruct:"layout=2006-01-02"` or,
+// together with the column name, `csvstruct:"date,layout=2006-01-02"`.
+func WithTimeLayout(layout string) Option {
+	return func(o *options) {
+		o.timeLayout = layout
+	}
+}
@@ -0,0 +1,146 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Event struct {
+	Starts  time.Time
+	Ends    time.Time
+	Release time.Time `csvstruct:"release_date,layout=Jan 2, 2006"`
+}
+
+type Season struct {
+	Event *Event
+}
+
+func TestReaderTime(t *testing.T) {
+	const data = `Event.Starts,Event.Ends,Event.release_date
+2024-03-01T10:00:00Z,,"Mar 15, 2024"
+`
+
+	reader := csvstruct.NewReader[Season](csv.NewReader(strings.NewReader(data)))
+
+	got, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	want := []Season{{&Event{
+		Starts:  time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		Release: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+	}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadAll() diff = %v", diff)
+	}
+}
+
+func TestWithTimeLayout(t *testing.T) {
+	const data = `Event.Starts,Event.release_date
+2024-03-01,"Mar 15, 2024"
+2024-13-01,
+`
+
+	reader := csvstruct.NewReader[Season](csv.NewReader(strings.NewReader(data)), csvstruct.WithTimeLayout(time.DateOnly))
+
+	var got Season
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Season{&Event{
+		Starts:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Release: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+
+	if err := reader.Read(&got); err == nil || !strings.HasPrefix(err.Error(), "line 3, column 1 (Event.Starts): ") {
+		t.Errorf("Read() err = %v; want error at line 3, column 1", err)
+	}
+}
+
+func TestWriterTime(t *testing.T) {
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Season](csv.NewWriter(&buf))
+
+	event := &Event{
+		Starts:  time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		Release: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+	if err := writer.Write(Season{event}); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want := `Event.Starts,Event.Ends,Event.release_date
+2024-03-01T10:00:00Z,,"Mar 15, 2024"
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+}
+
+type Cooldown struct {
+	Duration time.Duration
+	Delay    time.Duration
+}
+
+type Timer struct {
+	Cooldown *Cooldown
+}
+
+func TestReaderDuration(t *testing.T) {
+	const data = `Cooldown.Duration,Cooldown.Delay
+1.5s,250ms
+2m,
+10,
+`
+
+	reader := csvstruct.NewReader[Timer](csv.NewReader(strings.NewReader(data)))
+
+	for _, want := range []Timer{
+		{&Cooldown{1500 * time.Millisecond, 250 * time.Millisecond}},
+		{&Cooldown{2 * time.Minute, 0}},
+	} {
+		var got Timer
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Read() diff = %v", diff)
+		}
+	}
+
+	// Durations require a unit.
+	var got Timer
+	if err := reader.Read(&got); err == nil || !strings.HasPrefix(err.Error(), "line 4, column 1 (Cooldown.Duration): ") {
+		t.Errorf("Read() err = %v; want error at line 4, column 1", err)
+	}
+}
+
+func TestWriterDuration(t *testing.T) {
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Timer](csv.NewWriter(&buf))
+	if err := writer.Write(Timer{&Cooldown{1500 * time.Millisecond, 0}}); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	const want = "Cooldown.Duration,Cooldown.Delay\n1.5s,0s\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+}
@@ -0,0 +1,56 @@
+package csvstruct
+
+import "time"
+
+// SlowRow is a data row whose decoding took at least the threshold given with
+// WithRowTiming.
+type SlowRow struct {
+	// Line of the row in the CSV data, starting at 1.
+	Line int
+	// Time that it took to decode the row, including the validation and the
+	// middleware.
+	Duration time.Duration
+	// Qualified name of the column of the largest cell of the row, e.g.,
+	// 'MyComponent.MyField', and the size of that cell in bytes, e.g., to find
+	// huge JSON cells.
+	LargestColumn string
+	LargestCell   int
+}
+
+// largestCell records the column and the size of the largest cell of `row`,
+// which is being decoded. Only used with the WithRowTiming option.
+func (r *Reader[T]) largestCell(row []string) {
+	r.largestColumn, r.largestSize = "", 0
+	for columnNum, cell := range row {
+		if len(cell) > r.largestSize {
+			r.largestColumn, r.largestSize = r.colDescriptors[columnNum].qualName(), len(cell)
+		}
+	}
+}
+
+// timeRow returns the time that it took to decode the row that started to be
+// decoded at `start`, and records the row in the statistics if it's slow, or
+// returns 0 without the WithRowTiming option.
+func (r *Reader[T]) timeRow(start time.Time) time.Duration {
+	if !r.options.rowTiming {
+		return 0
+	}
+
+	duration := time.Since(start)
+	if duration >= r.options.slowRowThreshold {
+		r.stats.SlowRows = append(r.stats.SlowRows, SlowRow{r.fieldLine(0), duration, r.largestColumn, r.largestSize})
+	}
+	return duration
+}
+
+// WithRowTiming measures the time that it takes to decode each data row,
+// which is reported in the Duration of the EventRowDecoded events, e.g., for
+// metrics, and in the DecodeTime of TableStats. Rows whose decoding takes at
+// least `threshold` are reported in the SlowRows of TableStats, e.g., to find
+// pathological content that slows loading.
+func WithRowTiming(threshold time.Duration) Option {
+	return func(o *options) {
+		o.rowTiming = true
+		o.slowRowThreshold = threshold
+	}
+}
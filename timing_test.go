@@ -0,0 +1,62 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/jabolopes/csvstruct"
+)
+
+func TestWithRowTiming(t *testing.T) {
+	const data = `Info.Name,Info.Class
+Alex,Fighter
+Jayden,Sorcerer of the Seven Seas
+`
+
+	var durations time.Duration
+	observer := func(event csvstruct.Event) {
+		if event.Kind == csvstruct.EventRowDecoded {
+			durations += event.Duration
+		}
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithRowTiming(0), csvstruct.WithObserver(observer))
+	if _, err := reader.ReadAll(); err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	stats := reader.Stats()
+	want := []csvstruct.SlowRow{
+		{Line: 2, LargestColumn: "Info.Class", LargestCell: 7},
+		{Line: 3, LargestColumn: "Info.Class", LargestCell: 26},
+	}
+	if diff := cmp.Diff(want, stats.SlowRows, cmpopts.IgnoreFields(csvstruct.SlowRow{}, "Duration")); diff != "" {
+		t.Errorf("Stats().SlowRows diff = %v", diff)
+	}
+
+	if stats.DecodeTime != durations {
+		t.Errorf("Stats().DecodeTime = %v; want %v", stats.DecodeTime, durations)
+	}
+	if got := stats.SlowRows[0].Duration + stats.SlowRows[1].Duration; got != stats.DecodeTime {
+		t.Errorf("SlowRows durations = %v; want %v", got, stats.DecodeTime)
+	}
+}
+
+func TestWithRowTiming_Threshold(t *testing.T) {
+	const data = `Info.Name,Info.Class
+Alex,Fighter
+`
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithRowTiming(time.Hour))
+	if _, err := reader.ReadAll(); err != nil {
+		t.Fatalf("ReadAll() err = %v; want %v", err, nil)
+	}
+
+	if got := reader.Stats().SlowRows; got != nil {
+		t.Errorf("Stats().SlowRows = %v; want %v", got, nil)
+	}
+}
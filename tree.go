@@ -0,0 +1,119 @@
+package csvstruct
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Node is a node in a tree of rows, e.g., a tech tree or a nested menu.
+type Node[T any] struct {
+	// Row of this node.
+	Value T
+	// Children of this node, in the same order as the rows were given.
+	Children []*Node[T]
+}
+
+// BuildTree assembles rows into trees according to a parent relation and
+// returns the roots of the trees, in the same order as the rows were given.
+//
+// The key of each row is computed by `keyFn`. The parent of each row is
+// computed by `parentFn`, which returns the key of the parent row and true, or
+// false if the row is a root, e.g., because the row's Parent column is empty.
+//
+// Returns an error describing all the problems found if two rows have the same
+// key, if a row's parent doesn't exist (orphan), or if rows are their own
+// ancestors (cycle).
+func BuildTree[T any, K comparable](rows []T, keyFn func(*T) K, parentFn func(*T) (K, bool)) ([]*Node[T], error) {
+	var errs []error
+
+	nodes := make([]*Node[T], len(rows))
+	index := make(map[K]int, len(rows))
+	for i := range rows {
+		nodes[i] = &Node[T]{Value: rows[i]}
+
+		key := keyFn(&rows[i])
+		if _, ok := index[key]; ok {
+			errs = append(errs, fmt.Errorf("duplicate row key %v", key))
+			continue
+		}
+		index[key] = i
+	}
+
+	// Parent of each row, or -1 if the row is a root or an orphan.
+	parents := make([]int, len(rows))
+	// Children of each row.
+	children := make([][]int, len(rows))
+
+	var roots []*Node[T]
+	for i := range rows {
+		parents[i] = -1
+
+		parentKey, ok := parentFn(&rows[i])
+		if !ok {
+			roots = append(roots, nodes[i])
+			continue
+		}
+
+		parent, ok := index[parentKey]
+		if !ok {
+			errs = append(errs, fmt.Errorf("row %v is an orphan: parent %v not found", keyFn(&rows[i]), parentKey))
+			continue
+		}
+
+		parents[i] = parent
+		children[parent] = append(children[parent], i)
+		nodes[parent].Children = append(nodes[parent].Children, nodes[i])
+	}
+
+	// Rows that are not reachable from a root (or an orphan) are either in a
+	// cycle or descend from a cycle.
+	reachable := make([]bool, len(rows))
+	var mark func(int)
+	mark = func(row int) {
+		if reachable[row] {
+			return
+		}
+		reachable[row] = true
+		for _, child := range children[row] {
+			mark(child)
+		}
+	}
+	for i := range rows {
+		if parents[i] < 0 {
+			mark(i)
+		}
+	}
+
+	for i := range rows {
+		if reachable[i] {
+			continue
+		}
+
+		// Walk up until a row repeats, which is where the cycle starts.
+		seen := map[int]bool{}
+		start := i
+		for !seen[start] {
+			seen[start] = true
+			start = parents[start]
+		}
+
+		keys := []string{fmt.Sprint(keyFn(&rows[start]))}
+		for row := parents[start]; ; row = parents[row] {
+			keys = append(keys, fmt.Sprint(keyFn(&rows[row])))
+			if row == start {
+				break
+			}
+		}
+		errs = append(errs, fmt.Errorf("parent cycle: %s", strings.Join(keys, " -> ")))
+
+		// Descendants of the cycle are not reported separately.
+		mark(start)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return roots, nil
+}
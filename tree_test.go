@@ -0,0 +1,80 @@
+package csvstruct_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Tech struct {
+	Name   string
+	Parent string
+}
+
+func techKey(t *Tech) string { return t.Name }
+
+func techParent(t *Tech) (string, bool) { return t.Parent, len(t.Parent) > 0 }
+
+func TestBuildTree(t *testing.T) {
+	rows := []Tech{
+		{"Bronze", ""},
+		{"Iron", "Bronze"},
+		{"Steel", "Iron"},
+		{"Pottery", ""},
+		{"Wheel", "Bronze"},
+	}
+
+	want := []*csvstruct.Node[Tech]{
+		{
+			Value: Tech{"Bronze", ""},
+			Children: []*csvstruct.Node[Tech]{
+				{
+					Value:    Tech{"Iron", "Bronze"},
+					Children: []*csvstruct.Node[Tech]{{Value: Tech{"Steel", "Iron"}}},
+				},
+				{Value: Tech{"Wheel", "Bronze"}},
+			},
+		},
+		{Value: Tech{"Pottery", ""}},
+	}
+
+	got, err := csvstruct.BuildTree(rows, techKey, techParent)
+	if err != nil {
+		t.Fatalf("BuildTree() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("BuildTree() diff = %v", diff)
+	}
+}
+
+func TestBuildTree_Errors(t *testing.T) {
+	rows := []Tech{
+		{"Bronze", ""},
+		{"Magic", "Alchemy"},
+		{"A", "C"},
+		{"B", "A"},
+		{"C", "B"},
+		{"D", "C"},
+	}
+
+	_, err := csvstruct.BuildTree(rows, techKey, techParent)
+	if err == nil {
+		t.Fatalf("BuildTree() err = %v; want error", err)
+	}
+
+	for _, want := range []string{
+		"row Magic is an orphan: parent Alchemy not found",
+		"parent cycle: A -> C -> B -> A",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("BuildTree() err = %v; want %q", err, want)
+		}
+	}
+
+	if got, want := strings.Count(err.Error(), "\n")+1, 2; got != want {
+		t.Errorf("BuildTree() errors = %d; want %d", got, want)
+	}
+}
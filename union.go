@@ -0,0 +1,120 @@
+package csvstruct
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// union describes a component field of interface type, e.g., `any`, whose
+// concrete type is chosen by a sibling field of the same component, e.g., the
+// field 'Effect.Params' whose type is chosen by the field 'Effect.Type'.
+type union struct {
+	// Name of the sibling field that contains the name of the concrete type,
+	// from the field's `union` tag.
+	typeField string
+	// Column number of the sibling field.
+	typeColumn int
+	// Concrete types indexed by name, from the WithUnion option.
+	types map[string]reflect.Type
+}
+
+// resolveUnion finds the column of the sibling field of the union field in
+// column `columnNum`, if it's a union field.
+func (r *Reader[T]) resolveUnion(columnNum int) error {
+	descriptor := &r.colDescriptors[columnNum]
+	if descriptor.union == nil {
+		return nil
+	}
+
+	descriptor.union.typeColumn = -1
+	for i, sibling := range r.colDescriptors {
+		if sibling.componentName == descriptor.componentName && sibling.fieldName == descriptor.union.typeField {
+			descriptor.union.typeColumn = i
+			return nil
+		}
+	}
+
+	return fmt.Errorf("column %s requires column %s.%s with the name of its type", descriptor.qualName(), descriptor.componentName, descriptor.union.typeField)
+}
+
+// parseUnion parses the non-empty `cell` of a union field into the concrete
+// type named by the sibling column of `row`. The sibling cell is empty if
+// `row` is shorter than the CSV header, e.g., with the WithSections option.
+func (r *Reader[T]) parseUnion(descriptor *colDescriptor, row []string, cell string) (interface{}, error) {
+	var typeName string
+	if descriptor.union.typeColumn < len(row) {
+		typeName = row[descriptor.union.typeColumn]
+	}
+	if len(typeName) == 0 {
+		return nil, fmt.Errorf("column %s.%s is empty; want the name of the type", descriptor.componentName, descriptor.union.typeField)
+	}
+
+	typ, ok := descriptor.union.types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q in column %s.%s", typeName, descriptor.componentName, descriptor.union.typeField)
+	}
+
+	dst := reflect.New(typ).Elem()
+	if codec := lookupCodec(r.options.codecs, typ); codec != nil {
+		if err := codec.Decode(cell, dst); err != nil {
+			return nil, err
+		}
+		return dst.Interface(), nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.ParseInt(cell, 10, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		dst.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, err := strconv.ParseUint(cell, 10, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		dst.SetUint(number)
+	case reflect.Float32, reflect.Float64:
+		number, err := strconv.ParseFloat(cell, typ.Bits())
+		if err != nil {
+			return nil, err
+		}
+		dst.SetFloat(number)
+	case reflect.String:
+		dst.SetString(cell)
+	default:
+		// Other types, e.g., structs, are encoded as JSON.
+		if err := json.Unmarshal([]byte(cell), dst.Addr().Interface()); err != nil {
+			return nil, err
+		}
+	}
+
+	return dst.Interface(), nil
+}
+
+// formatUnion formats the concrete value of a union field as a CSV cell, in
+// the format that parseUnion parses.
+func formatUnion(codecs map[reflect.Type]Codec, value reflect.Value) (string, error) {
+	if value.IsNil() {
+		return "", nil
+	}
+	value = value.Elem()
+
+	if codec := lookupCodec(codecs, value.Type()); codec != nil {
+		return codec.Encode(value)
+	}
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64, reflect.String:
+		return formatCell(value), nil
+	}
+
+	data, err := json.Marshal(value.Interface())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,137 @@
+package csvstruct_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type HealParams struct {
+	Amount int
+}
+
+type DamageParams struct {
+	Dice csvstruct.Dice
+}
+
+type Effect struct {
+	Type   string
+	Params any `union:"Type"`
+}
+
+type Spell struct {
+	Info   *Info
+	Effect *Effect
+}
+
+var effectTypes = map[string]reflect.Type{
+	"Heal":   reflect.TypeFor[HealParams](),
+	"Damage": reflect.TypeFor[DamageParams](),
+	"Speed":  reflect.TypeFor[float64](),
+}
+
+func TestReaderUnion(t *testing.T) {
+	const data = `Info.Name,Effect.Type,Effect.Params
+Cure,Heal,"{""Amount"":10}"
+Fireball,Damage,"{""Dice"":{""Count"":2,""Sides"":6,""Modifier"":0}}"
+Haste,Speed,1.5
+Rest,Heal,
+`
+
+	want := []Spell{
+		{&Info{Name: "Cure"}, &Effect{"Heal", HealParams{10}}},
+		{&Info{Name: "Fireball"}, &Effect{"Damage", DamageParams{csvstruct.Dice{2, 6, 0}}}},
+		{&Info{Name: "Haste"}, &Effect{"Speed", 1.5}},
+		{&Info{Name: "Rest"}, &Effect{"Heal", nil}},
+	}
+
+	reader := csvstruct.NewReader[Spell](csv.NewReader(strings.NewReader(data)), csvstruct.WithUnion("Effect.Params", effectTypes))
+
+	for _, want := range want {
+		var got Spell
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+}
+
+func TestReaderUnion_Errors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"Effect.Type,Effect.Params\nPoison,1\n", `unknown type "Poison" in column Effect.Type`},
+		{"Effect.Type,Effect.Params\n,1\n", "column Effect.Type is empty"},
+		{"Effect.Type,Effect.Params\nHeal,{\n", "column 2 (Effect.Params)"},
+		{"Effect.Params\n1\n", "column Effect.Params requires column Effect.Type"},
+	}
+
+	for _, test := range tests {
+		reader := csvstruct.NewReader[Spell](csv.NewReader(strings.NewReader(test.data)), csvstruct.WithUnion("Effect.Params", effectTypes))
+
+		var got Spell
+		if err := reader.Read(&got); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Read() err = %v; want error containing %q", err, test.want)
+		}
+	}
+}
+
+func TestReaderUnion_ShortRow(t *testing.T) {
+	const data = "Effect.Params,Effect.Type\n1\n"
+
+	reader := csvstruct.NewReader[Spell](csv.NewReader(strings.NewReader(data)), csvstruct.WithUnion("Effect.Params", effectTypes), csvstruct.WithSections())
+
+	var got Spell
+	if err := reader.Read(&got); err == nil || !strings.Contains(err.Error(), "column Effect.Type is empty") {
+		t.Errorf("Read() err = %v; want error containing %q", err, "column Effect.Type is empty")
+	}
+}
+
+func TestReaderUnion_MissingTypes(t *testing.T) {
+	reader := csvstruct.NewReader[Spell](csv.NewReader(strings.NewReader("Effect.Type,Effect.Params\nHeal,1\n")))
+
+	var got Spell
+	if err := reader.Read(&got); err == nil {
+		t.Errorf("Read() err = %v; want error", err)
+	}
+}
+
+func TestWriterUnion(t *testing.T) {
+	spells := []Spell{
+		{&Info{Name: "Cure"}, &Effect{"Heal", HealParams{10}}},
+		{&Info{Name: "Haste"}, &Effect{"Speed", 1.5}},
+		{&Info{Name: "Rest"}, &Effect{"Heal", nil}},
+	}
+
+	var buffer bytes.Buffer
+	writer := csvstruct.NewWriter[Spell](csv.NewWriter(&buffer))
+	for _, spell := range spells {
+		if err := writer.Write(spell); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	reader := csvstruct.NewReader[Spell](csv.NewReader(&buffer), csvstruct.WithUnion("Effect.Params", effectTypes))
+	for _, want := range spells {
+		var got Spell
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package csvstruct
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// unitInfo describes a unit of measurement.
+type unitInfo struct {
+	// Dimension of the unit, e.g., 'time'. Only units of the same dimension can
+	// be converted.
+	dimension string
+	// Value of the unit in the base unit of its dimension, e.g., 0.001 for
+	// milliseconds, whose base unit is seconds.
+	factor float64
+}
+
+// knownUnits are the units that can be converted, indexed by name.
+var knownUnits = map[string]unitInfo{
+	"ns":      {"time", 1e-9},
+	"us":      {"time", 1e-6},
+	"ms":      {"time", 1e-3},
+	"s":       {"time", 1},
+	"seconds": {"time", 1},
+	"min":     {"time", 60},
+	"h":       {"time", 3600},
+	"mm":      {"length", 1e-3},
+	"cm":      {"length", 1e-2},
+	"m":       {"length", 1},
+	"km":      {"length", 1e3},
+	"g":       {"mass", 1e-3},
+	"kg":      {"mass", 1},
+	"%":       {"ratio", 1e-2},
+	"ratio":   {"ratio", 1},
+}
+
+// unitScale returns the factor that converts values in unit `from` to unit
+// `to`.
+func unitScale(from, to string) (float64, error) {
+	fromInfo, ok := knownUnits[from]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+
+	toInfo, ok := knownUnits[to]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+
+	if fromInfo.dimension != toInfo.dimension {
+		return 0, fmt.Errorf("unit %q can't be converted to unit %q", from, to)
+	}
+
+	return fromInfo.factor / toInfo.factor, nil
+}
+
+// scaleValue converts a value decoded from a cell, which is either an int64, a
+// uint64, or a float64, by the given factor. Integers must remain integers,
+// e.g., 1500ms can't be converted to an integer number of seconds, and they
+// must fit in the field's type `typ`.
+func scaleValue(value interface{}, scale float64, typ reflect.Type) (interface{}, error) {
+	switch value := value.(type) {
+	case int64:
+		scaled := float64(value) * scale
+		if scaled != math.Round(scaled) {
+			return nil, fmt.Errorf("value %d converts to %v which is not an integer", value, scaled)
+		}
+		if scaled < math.MinInt64 || scaled >= math.MaxInt64 || reflect.Zero(typ).OverflowInt(int64(scaled)) {
+			return nil, fmt.Errorf("value %d converts to %v which overflows %s", value, scaled, typ.String())
+		}
+		return int64(scaled), nil
+	case uint64:
+		scaled := float64(value) * scale
+		if scaled != math.Round(scaled) {
+			return nil, fmt.Errorf("value %d converts to %v which is not an integer", value, scaled)
+		}
+		if scaled >= math.MaxUint64 || reflect.Zero(typ).OverflowUint(uint64(scaled)) {
+			return nil, fmt.Errorf("value %d converts to %v which overflows %s", value, scaled, typ.String())
+		}
+		return uint64(scaled), nil
+	case float64:
+		return value * scale, nil
+	}
+	return value, nil
+}
+
+// readUnits reads the units row that follows the CSV header and computes the
+// conversions of the columns whose fields declare a canonical unit.
+func (r *Reader[T]) readUnits() error {
+	row, err := r.reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read units row: %w", err)
+	}
+
+	row, err = r.trimTrailingColumns(row)
+	if err != nil {
+		return err
+	}
+
+	// Rows can be wider than the CSV header, e.g., with the WithSections
+	// option, but only with empty cells.
+	for columnNum := len(r.colDescriptors); columnNum < len(row); columnNum++ {
+		if len(row[columnNum]) > 0 {
+			return fmt.Errorf("line %d, column %d: units row has unit %q beyond the last column of the CSV header", r.fieldLine(columnNum), columnNum+1, row[columnNum])
+		}
+	}
+	return r.applyUnits(row[:min(len(row), len(r.colDescriptors))])
+}
+
+// applyUnits sets the units of the columns of the current table from `row`,
+// which has the unit of each column of the CSV header, or an empty string for
+// columns without units, and computes the conversions of the columns whose
+// fields declare a canonical unit. `row` can be shorter than the CSV header.
+func (r *Reader[T]) applyUnits(row []string) error {
+	r.units = map[string]string{}
+
+	var headerErr HeaderError
+	for columnNum, unit := range row {
+		descriptor := &r.colDescriptors[columnNum]
+		if len(unit) > 0 {
+			r.units[descriptor.qualName()] = unit
+		}
+
+		if len(unit) == 0 || len(descriptor.unit) == 0 || unit == descriptor.unit {
+			continue
+		}
+
+		scale, err := unitScale(unit, descriptor.unit)
+		if err != nil {
+			headerErr.Columns = append(headerErr.Columns, &ColumnError{Column: columnNum + 1, Name: r.header[columnNum], Err: err})
+			continue
+		}
+		descriptor.unitScale = scale
+	}
+
+	if len(headerErr.Columns) > 0 {
+		return &headerErr
+	}
+	return nil
+}
+
+// columnUnits returns the unit of each column of the CSV header, or an empty
+// string for columns without units, e.g., to store them in checkpoints. It's
+// nil if there is no units row.
+func (r *Reader[T]) columnUnits() []string {
+	if r.units == nil {
+		return nil
+	}
+
+	units := make([]string, len(r.colDescriptors))
+	for columnNum := range r.colDescriptors {
+		units[columnNum] = r.units[r.colDescriptors[columnNum].qualName()]
+	}
+	return units
+}
+
+// Units returns the units of the columns, indexed by qualified name, e.g.,
+// 'seconds' for 'Ability.Cooldown', as given by the units row of the current
+// table. Columns without units are omitted. Only used with the WithUnits
+// option.
+func (r *Reader[T]) Units() map[string]string {
+	return r.units
+}
+
+// WithUnits enables units rows. The row that follows each CSV header is a
+// units row, which contains the unit of each column, e.g., 'seconds', or an
+// empty cell for columns without units. Reader.Units returns the units.
+//
+// Numeric component fields tagged with `unit:"name"` declare their canonical
+// unit, e.g., `unit:"s"`, and cells in other units of the same dimension are
+// converted to it, e.g., a cell '1500' in a column whose unit is 'ms' is
+// decoded as 1.5. Integer fields must convert to integers. The known units are
+// ns, us, ms, s (or seconds), min, h, mm, cm, m, km, g, kg, % and ratio.
+func WithUnits() Option {
+	return func(o *options) {
+		o.units = true
+	}
+}
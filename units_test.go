@@ -0,0 +1,161 @@
+package csvstruct_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+type Ability struct {
+	Name     string
+	Cooldown float64 `unit:"s"`
+	Range    int     `unit:"m"`
+	Damage   int
+}
+
+type Skill struct {
+	Ability *Ability
+}
+
+func TestReaderUnits(t *testing.T) {
+	const data = `Ability.Name,Ability.Cooldown,Ability.Range,Ability.Damage
+,ms,km,points
+Fireball,1500,2,30
+Blink,250,,
+`
+
+	want := []Skill{
+		{&Ability{"Fireball", 1.5, 2000, 30}},
+		{&Ability{"Blink", 0.25, 0, 0}},
+	}
+
+	reader := csvstruct.NewReader[Skill](csv.NewReader(strings.NewReader(data)), csvstruct.WithUnits())
+
+	for _, want := range want {
+		var got Skill
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+
+	wantUnits := map[string]string{"Ability.Cooldown": "ms", "Ability.Range": "km", "Ability.Damage": "points"}
+	if diff := cmp.Diff(wantUnits, reader.Units()); diff != "" {
+		t.Errorf("Units() diff = %v", diff)
+	}
+}
+
+func TestReaderUnits_Errors(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"Ability.Cooldown\nkg\n1\n", `unit "kg" can't be converted to unit "s"`},
+		{"Ability.Cooldown\nfortnights\n1\n", `unknown unit "fortnights"`},
+		{"Ability.Range\ncm\n150\n", "line 3, column 1 (Ability.Range): value 150 converts to 1.5 which is not an integer"},
+	}
+
+	for _, test := range tests {
+		reader := csvstruct.NewReader[Skill](csv.NewReader(strings.NewReader(test.data)), csvstruct.WithUnits())
+
+		var got Skill
+		if err := reader.Read(&got); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("Read() err = %v; want error containing %q", err, test.want)
+		}
+	}
+}
+
+func TestReaderUnits_WideRow(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"Ability.Cooldown\nms,\n1500\n", ""},
+		{"Ability.Cooldown\nms,km\n1500\n", `line 2, column 2: units row has unit "km" beyond the last column of the CSV header`},
+	}
+
+	for _, test := range tests {
+		reader := csvstruct.NewReader[Skill](csv.NewReader(strings.NewReader(test.data)), csvstruct.WithUnits(), csvstruct.WithSections())
+
+		var got Skill
+		err := reader.Read(&got)
+		if len(test.want) == 0 && err != nil || len(test.want) > 0 && (err == nil || !strings.Contains(err.Error(), test.want)) {
+			t.Errorf("Read() err = %v; want error containing %q", err, test.want)
+		}
+	}
+}
+
+func TestResumeReader_Units(t *testing.T) {
+	const data = `Ability.Name,Ability.Cooldown,Ability.Range
+,ms,km
+Fireball,1500,2
+Blink,2500,
+`
+
+	reader := csvstruct.NewReader[Skill](csv.NewReader(strings.NewReader(data)), csvstruct.WithUnits())
+
+	var got Skill
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	resumed, err := csvstruct.ResumeReader[Skill](strings.NewReader(data), reader.Checkpoint(), csvstruct.WithUnits())
+	if err != nil {
+		t.Fatalf("ResumeReader() err = %v; want %v", err, nil)
+	}
+	if err := resumed.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	want := Skill{&Ability{Name: "Blink", Cooldown: 2.5}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+
+	// The units are part of the schema.
+	if _, err := csvstruct.ResumeReader[Skill](strings.NewReader(data), reader.Checkpoint()); !errors.Is(err, csvstruct.ErrSchemaMismatch) {
+		t.Errorf("ResumeReader() err = %v; want %v", err, csvstruct.ErrSchemaMismatch)
+	}
+}
+
+func TestReadRowAt_Units(t *testing.T) {
+	const data = `Ability.Name,Ability.Cooldown,Ability.Range
+,ms,km
+Fireball,1500,2
+Blink,2500,
+`
+
+	reader := csvstruct.NewReader[Skill](csv.NewReader(strings.NewReader(data)), csvstruct.WithUnits())
+
+	index, err := reader.BuildIndex()
+	if err != nil {
+		t.Fatalf("BuildIndex() err = %v; want %v", err, nil)
+	}
+
+	var buf bytes.Buffer
+	if err := csvstruct.SaveIndex(&buf, index); err != nil {
+		t.Fatalf("SaveIndex() err = %v; want %v", err, nil)
+	}
+	loaded, err := csvstruct.LoadIndex(&buf)
+	if err != nil {
+		t.Fatalf("LoadIndex() err = %v; want %v", err, nil)
+	}
+
+	var got Skill
+	if err := csvstruct.ReadRowAt(strings.NewReader(data), loaded, 0, &got, csvstruct.WithUnits()); err != nil {
+		t.Fatalf("ReadRowAt() err = %v; want %v", err, nil)
+	}
+
+	want := Skill{&Ability{Name: "Fireball", Cooldown: 1.5, Range: 2000}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadRowAt() diff = %v", diff)
+	}
+}
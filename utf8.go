@@ -0,0 +1,46 @@
+package csvstruct
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// UTF8Policy is the handling of cells that are not valid UTF-8, e.g., in
+// corrupted exports.
+type UTF8Policy int
+
+const (
+	// UTF8Ignore decodes cells as they are. This is the default.
+	UTF8Ignore UTF8Policy = iota
+	// UTF8Reject makes Read return an error that includes the row and column
+	// of the cell.
+	UTF8Reject
+	// UTF8Sanitize replaces each invalid sequence with the Unicode replacement
+	// character (U+FFFD), and notifies observers with an EventWarning.
+	UTF8Sanitize
+)
+
+// WithUTF8 sets the handling of cells that are not valid UTF-8. See
+// UTF8Policy.
+func WithUTF8(policy UTF8Policy) Option {
+	return func(o *options) {
+		o.utf8Policy = policy
+	}
+}
+
+// checkUTF8 handles the cell in column `columnNum` according to the UTF-8
+// policy, and returns the cell contents that should be decoded.
+func (r *Reader[T]) checkUTF8(columnNum int, cell string) (string, error) {
+	if r.options.utf8Policy == UTF8Ignore || utf8.ValidString(cell) {
+		return cell, nil
+	}
+
+	err := fmt.Errorf("invalid UTF-8 in %q", cell)
+	if r.options.utf8Policy == UTF8Reject {
+		return "", err
+	}
+
+	r.emit(Event{Kind: EventWarning, Line: r.fieldLine(columnNum), Err: r.cellError(columnNum, err)})
+	return strings.ToValidUTF8(cell, string(utf8.RuneError)), nil
+}
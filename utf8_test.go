@@ -0,0 +1,56 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+const invalidUTF8Data = "Info.Name,Info.Class\nAlex,Fi\xffghter\n"
+
+func TestReaderUTF8(t *testing.T) {
+	tests := []struct {
+		policy       csvstruct.UTF8Policy
+		want         Prefab
+		wantWarnings int
+	}{
+		{csvstruct.UTF8Ignore, Prefab{Info: &Info{"Alex", "Fi\xffghter"}}, 0},
+		{csvstruct.UTF8Sanitize, Prefab{Info: &Info{"Alex", "Fi\uFFFDghter"}}, 1},
+	}
+
+	for _, test := range tests {
+		var warnings int
+		observer := func(event csvstruct.Event) {
+			if event.Kind == csvstruct.EventWarning {
+				warnings++
+			}
+		}
+
+		reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(invalidUTF8Data)), csvstruct.WithUTF8(test.policy), csvstruct.WithObserver(observer))
+
+		var got Prefab
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("Read() diff = %v", diff)
+		}
+
+		if warnings != test.wantWarnings {
+			t.Errorf("warnings = %d; want %d", warnings, test.wantWarnings)
+		}
+	}
+}
+
+func TestReaderUTF8_Reject(t *testing.T) {
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(invalidUTF8Data)), csvstruct.WithUTF8(csvstruct.UTF8Reject))
+
+	var got Prefab
+	if err := reader.Read(&got); err == nil || !strings.Contains(err.Error(), "line 2, column 2 (Info.Class): invalid UTF-8") {
+		t.Errorf("Read() err = %v; want invalid UTF-8 error", err)
+	}
+}
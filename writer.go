@@ -0,0 +1,378 @@
+package csvstruct
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// writeColumn describes a column written by a Writer.
+type writeColumn struct {
+	// Qualified name of the column, e.g., 'MyComponent.MyField'.
+	qualName string
+	// Index of the component field in `T`.
+	componentIndex int
+	// Index of the field in the component, or -1 if the column only marks the
+	// presence of the component.
+	fieldIndex int
+	// Codec of the field's type, or nil if there is none.
+	codec Codec
+	// Whether the field is a union, i.e., it's tagged with `union`.
+	isUnion bool
+	// Layout of a time.Time field without a codec, or empty otherwise.
+	timeLayout string
+	// Whether the field is redacted, i.e., it's tagged with `redact` and the
+	// WithRedaction option was given.
+	isRedacted bool
+	// Cell written instead of the field's value if the field is redacted, from
+	// the field's `redact` tag.
+	redaction string
+}
+
+// isUnknown returns whether the column is not written from `T`, i.e., it's a
+// column given with WithColumnOrder whose cells are passed through.
+func (c *writeColumn) isUnknown() bool {
+	return c.componentIndex < 0
+}
+
+// isWritableField returns whether a component field of type `typ` can be
+// written without a codec, i.e., whether the Reader can parse it back.
+func isWritableField(typ reflect.Type) bool {
+	if typ == timeType {
+		return true
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+// formatCell formats a component field value as a CSV cell.
+func formatCell(value reflect.Value) string {
+	if value.Type() == durationType {
+		return time.Duration(value.Int()).String()
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64)
+	case reflect.String:
+		return value.String()
+	}
+	return ""
+}
+
+// encoder converts values of type `T` to records of cells, in the format that
+// Reader parses. It's shared by Writer and the other writers of tables.
+type encoder[T any] struct {
+	// Columns derived from the type `T`.
+	columns []writeColumn
+	// Buffer for the record being encoded.
+	record []string
+	// Index of the field of `T` that captures the unknown columns, or -1 if
+	// there is none.
+	unknownField int
+	// Options given to the writer.
+	options writerOptions
+	// Error of the type `T` or of the options, e.g., a component selected with
+	// WithWriteComponents that `T` doesn't have. If there is one, it's returned
+	// by all writes.
+	err error
+}
+
+// newEncoder returns a new encoder configured with the given options.
+//
+// If the type `T` is not a struct or if the options select components or
+// fields that `T` doesn't have, the encoder has no columns and its `err` is
+// set, so that writers report it from their first write, like Reader reports
+// the errors of its options from Read.
+func newEncoder[T any](opts []WriterOption) encoder[T] {
+	var e encoder[T]
+	for _, opt := range opts {
+		opt(&e.options)
+	}
+	if err := e.createColumns(); err != nil {
+		e.columns, e.err = nil, err
+		return e
+	}
+	if e.options.columnOrder != nil {
+		e.orderColumns(e.options.columnOrder)
+	}
+	e.unknownField = unknownFieldIndex(reflect.TypeFor[T]())
+	e.record = make([]string, len(e.columns))
+	return e
+}
+
+// Writer writes component data as CSV data, in the format that Reader parses.
+//
+// This is thread compatible, i.e., it's safe for non-concurrent use and it can
+// be combined with external synchronization so it can be called concurrently.
+type Writer[T any] struct {
+	encoder[T]
+	// Underlying CSV writer.
+	writer *csv.Writer
+	// Whether the CSV header of the current table has been written.
+	hasHeader bool
+}
+
+// createColumns derives the columns from the type `T`.
+//
+// Each component, i.e., each exported field of `T` that is a struct or a
+// pointer to a struct, contributes one column per exported field of the
+// component that can be written, e.g., 'MyComponent.MyField'. Components
+// without any fields, e.g., marker components, contribute a single column,
+// e.g., 'MyComponent'.
+//
+// If components were selected with WithWriteComponents, only the selected
+// columns are created.
+//
+// Slices of components, i.e., repeated column groups, e.g., 'Drops[0].Item',
+// contribute no columns, because the number of groups depends on the data
+// rather than on `T`.
+func (e *encoder[T]) createColumns() error {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		return fmt.Errorf("type %s is not a struct", typ.String())
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		component := typ.Field(i)
+		if !component.IsExported() {
+			continue
+		}
+
+		componentType, ok := componentStruct(component.Type)
+		if !ok {
+			continue
+		}
+		if componentType.NumField() == 0 {
+			if e.isSelected(columnName(component), "") {
+				e.columns = append(e.columns, writeColumn{qualName: columnName(component), componentIndex: i, fieldIndex: -1})
+			}
+			continue
+		}
+
+		for j := 0; j < componentType.NumField(); j++ {
+			field := componentType.Field(j)
+			if !field.IsExported() {
+				continue
+			}
+
+			codec := lookupCodec(e.options.codecs, field.Type)
+			if codec == nil {
+				codec = newListCodec(e.options.codecs, nil, field.Type, e.options.listSeparator)
+			}
+			if codec == nil && !isPatternField(field) {
+				codec = newMapCodec(e.options.codecs, nil, field.Type, e.options.pairSeparator, e.options.keySeparator)
+			}
+			_, isUnion := field.Tag.Lookup("union")
+			isUnion = isUnion && field.Type.Kind() == reflect.Interface
+			if codec == nil && !isUnion && !isWritableField(field.Type) || !e.isSelected(columnName(component), columnName(field)) {
+				continue
+			}
+
+			column := writeColumn{qualName: columnName(component) + "." + columnName(field), componentIndex: i, fieldIndex: j, codec: codec, isUnion: isUnion}
+			if _, layout := parseTag(field); codec == nil && field.Type == timeType {
+				column.timeLayout = cmp.Or(layout, time.RFC3339)
+			}
+			if e.options.redaction {
+				column.redaction, column.isRedacted = field.Tag.Lookup("redact")
+			}
+			e.columns = append(e.columns, column)
+		}
+	}
+
+	for name, used := range e.options.components {
+		if !used {
+			return fmt.Errorf("type %s does not have a writable component or field %q", typ.String(), name)
+		}
+	}
+
+	return nil
+}
+
+// isSelected returns whether the given component field (or the component, if
+// `fieldName` is empty) is selected by WithWriteComponents, and records that
+// the selection was used.
+func (e *encoder[T]) isSelected(componentName, fieldName string) bool {
+	if e.options.components == nil {
+		return true
+	}
+
+	if _, ok := e.options.components[componentName]; ok {
+		e.options.components[componentName] = true
+		return true
+	}
+
+	qualName := componentName + "." + fieldName
+	if _, ok := e.options.components[qualName]; ok {
+		e.options.components[qualName] = true
+		return true
+	}
+
+	return false
+}
+
+// header returns the qualified names of the columns.
+func (e *encoder[T]) header() []string {
+	header := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		header[i] = column.qualName
+	}
+	return header
+}
+
+// encode converts `t` to a record of cells. The record is reused by the next
+// call.
+//
+// Nil components are encoded as empty cells. Marker components are encoded as
+// '1' when they are present, i.e., when they are non-nil pointers or values.
+func (e *encoder[T]) encode(t T) ([]string, error) {
+	// `t` is copied to an addressable value, so that the fields of value
+	// components can be encoded by methods with pointer receivers, e.g.,
+	// MarshalText.
+	value := reflect.New(reflect.TypeFor[T]()).Elem()
+	value.Set(reflect.ValueOf(t))
+	for i, column := range e.columns {
+		e.record[i] = ""
+		if column.isUnknown() {
+			if e.unknownField >= 0 {
+				e.record[i] = value.Field(e.unknownField).Interface().(map[string]string)[column.qualName]
+			}
+			continue
+		}
+
+		component := componentValue(value.Field(column.componentIndex))
+		if !component.IsValid() {
+			continue
+		}
+
+		if column.fieldIndex < 0 {
+			e.record[i] = "1"
+			continue
+		}
+
+		if column.isRedacted {
+			e.record[i] = column.redaction
+			continue
+		}
+
+		field := component.Field(column.fieldIndex)
+		if len(column.timeLayout) > 0 {
+			e.record[i] = formatTime(field, column.timeLayout)
+			continue
+		}
+		if column.codec == nil && !column.isUnion {
+			e.record[i] = formatCell(field)
+			continue
+		}
+
+		var cell string
+		var err error
+		if column.isUnion {
+			cell, err = formatUnion(e.options.codecs, field)
+		} else {
+			cell, err = column.codec.Encode(field)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", column.qualName, err)
+		}
+		e.record[i] = cell
+	}
+
+	return e.record, nil
+}
+
+// Header returns the CSV header that this writer writes before the first row of
+// each table.
+func (w *Writer[T]) Header() []string {
+	return w.header()
+}
+
+// WriteHeader writes the CSV header of the current table, unless it has already
+// been written, e.g., to write a table without rows, which Write can't do.
+func (w *Writer[T]) WriteHeader() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.hasHeader {
+		return nil
+	}
+	if err := w.writer.Write(w.Header()); err != nil {
+		return err
+	}
+	w.hasHeader = true
+	return nil
+}
+
+// Write writes `t` as a CSV row. Before the first row of each table, the CSV
+// header is written.
+//
+// Nil components are written as empty cells. Marker components are written as
+// '1' when they are present, i.e., when they are non-nil pointers or values.
+//
+// Writes are buffered, so Flush must be called to ensure that the data is
+// written to the underlying io.Writer.
+func (w *Writer[T]) Write(t T) error {
+	return w.WriteWithUnknown(t, nil)
+}
+
+// WriteSection ends the current table and writes a section separator, so that
+// the next table can be written to the same CSV data.
+//
+// If `name` is not empty, the separator is a '[name]' row, which Reader
+// recognizes when it's created with the WithSections option. It can also be
+// written before the first table. Otherwise, the separator is a blank line,
+// which is skipped by csv.Reader and therefore the caller must call
+// Reader.Clear at the right time to read the next table.
+//
+// Like NewTable, the next Write writes the CSV header again.
+func (w *Writer[T]) WriteSection(name string) error {
+	w.hasHeader = false
+
+	if len(name) == 0 {
+		return w.writer.Write(nil)
+	}
+	return w.writer.Write([]string{"[" + name + "]"})
+}
+
+// NewTable ends the current table and flushes it, so that the next Write
+// writes the CSV header again. This is the counterpart of Reader.Clear, i.e.,
+// no separator is written between tables. Use a new Writer sharing the same
+// csv.Writer to write the next table with a different type.
+func (w *Writer[T]) NewTable() error {
+	w.hasHeader = false
+	return w.Flush()
+}
+
+// Flush writes any buffered data to the underlying io.Writer and returns any
+// error that occurred during a previous Write or Flush.
+func (w *Writer[T]) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// NewWriter returns a new writer using the given `writer` as the underlying CSV
+// writer. The type `T` is the schema that is used to write the data.
+//
+// The writer can be configured with options, e.g., WithWriteComponents.
+// Repeated column groups, i.e., slices of components, are not written.
+//
+// If the type `T` is not a struct or if the options select components or
+// fields that `T` doesn't have, the first Write returns the error.
+func NewWriter[T any](writer *csv.Writer, opts ...WriterOption) *Writer[T] {
+	return &Writer[T]{encoder: newEncoder[T](opts), writer: writer}
+}
@@ -0,0 +1,417 @@
+package csvstruct_test
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jabolopes/csvstruct"
+)
+
+var testPrefabs = []Prefab{
+	{&Info{"Alex", "Fighter"}, &Attributes{100, 10}, nil},
+	{&Info{"Jayden", "Wizard"}, &Attributes{90, 20}, nil},
+	{&Info{"Mary", "Queen"}, nil, nil},
+	{&Info{"Player", ""}, nil, &Player{}},
+}
+
+func ExampleWriter() {
+	writer := csvstruct.NewWriter[Prefab](csv.NewWriter(os.Stdout))
+
+	for _, prefab := range testPrefabs {
+		if err := writer.Write(prefab); err != nil {
+			panic(err)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		panic(err)
+	}
+
+	// Output: Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player
+	// Alex,Fighter,100,10,
+	// Jayden,Wizard,90,20,
+	// Mary,Queen,,,
+	// Player,,,,1
+}
+
+func TestWriter(t *testing.T) {
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Prefab](csv.NewWriter(&buf))
+
+	for _, prefab := range testPrefabs {
+		if err := writer.Write(prefab); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(buf.String())))
+
+	var got []Prefab
+	for {
+		var prefab Prefab
+		err := reader.Read(&prefab)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+		got = append(got, prefab)
+	}
+
+	if diff := cmp.Diff(testPrefabs, got); diff != "" {
+		t.Fatalf("Read() diff = %v", diff)
+	}
+}
+
+func TestWriter_IntKinds(t *testing.T) {
+	want := Packet{&Sizes{-128, 32767, 1, 255, 65535, 4294967295, 18446744073709551615}}
+
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Packet](csv.NewWriter(&buf))
+	if err := writer.Write(want); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	reader := csvstruct.NewReader[Packet](csv.NewReader(strings.NewReader(buf.String())))
+
+	var got Packet
+	if err := reader.Read(&got); err != nil {
+		t.Fatalf("Read() err = %v; want %v", err, nil)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() diff = %v", diff)
+	}
+}
+
+func TestWriter_ValueComponents(t *testing.T) {
+	rows := []PlainPrefab{
+		{Info{"Alex", "Fighter"}, &Attributes{100, 10}, Player{}},
+		{Info{"Mary", "Queen"}, nil, Player{}},
+	}
+
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[PlainPrefab](csv.NewWriter(&buf))
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	// Value marker components are always written as present.
+	want := `Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player
+Alex,Fighter,100,10,1
+Mary,Queen,,,1
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Write() diff = %v", diff)
+	}
+}
+
+func TestWriter_Sections(t *testing.T) {
+	items := []Item{
+		{&Weapon{"Sword", csvstruct.Dice{1, 8, 1}}},
+		{&Weapon{"Dagger", csvstruct.Dice{1, 4, 0}}},
+	}
+
+	var buf strings.Builder
+	csvWriter := csv.NewWriter(&buf)
+
+	prefabWriter := csvstruct.NewWriter[Prefab](csvWriter)
+	if err := prefabWriter.WriteSection("Prefabs"); err != nil {
+		t.Fatalf("WriteSection() err = %v; want %v", err, nil)
+	}
+	for _, prefab := range testPrefabs {
+		if err := prefabWriter.Write(prefab); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	if err := prefabWriter.WriteSection("Items"); err != nil {
+		t.Fatalf("WriteSection() err = %v; want %v", err, nil)
+	}
+
+	itemWriter := csvstruct.NewWriter[Item](csvWriter)
+	for _, item := range items {
+		if err := itemWriter.Write(item); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	if err := itemWriter.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want := `[Prefabs]
+Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player
+Alex,Fighter,100,10,
+Jayden,Wizard,90,20,
+Mary,Queen,,,
+Player,,,,1
+[Items]
+Weapon.Name,Weapon.Damage
+Sword,1d8+1
+Dagger,1d4
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatalf("Write() diff = %v", diff)
+	}
+
+	csvReader := csv.NewReader(strings.NewReader(buf.String()))
+	reader := csvstruct.NewReader[Prefab](csvReader, csvstruct.WithSections())
+
+	for _, want := range testPrefabs {
+		var got Prefab
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+
+		if got, want := reader.Section(), "Prefabs"; got != want {
+			t.Fatalf("Section() = %q; want %q", got, want)
+		}
+	}
+
+	var prefab Prefab
+	if err := reader.Read(&prefab); err != csvstruct.ErrEndOfSection {
+		t.Fatalf("Read() err = %v; want %v", err, csvstruct.ErrEndOfSection)
+	}
+	if got, want := reader.Section(), "Items"; got != want {
+		t.Fatalf("Section() = %q; want %q", got, want)
+	}
+
+	itemReader := csvstruct.NewReader[Item](csvReader, csvstruct.WithSections())
+	for _, want := range items {
+		var got Item
+		if err := itemReader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+	}
+}
+
+func TestReader_Sections(t *testing.T) {
+	const data = `[Heroes]
+Info.Name
+Alex
+[Villains]
+Info.Name,Info.Class
+Mary,Queen
+`
+
+	want := []struct {
+		section string
+		prefab  Prefab
+	}{
+		{"Heroes", Prefab{Info: &Info{"Alex", ""}}},
+		{"Villains", Prefab{Info: &Info{"Mary", "Queen"}}},
+	}
+
+	reader := csvstruct.NewReader[Prefab](csv.NewReader(strings.NewReader(data)), csvstruct.WithSections())
+
+	for i, want := range want {
+		var got Prefab
+		err := reader.Read(&got)
+		if i > 0 {
+			if err != csvstruct.ErrEndOfSection {
+				t.Fatalf("Read() err = %v; want %v", err, csvstruct.ErrEndOfSection)
+			}
+
+			reader.Clear()
+			err = reader.Read(&got)
+		}
+		if err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want.prefab, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+
+		if got := reader.Section(); got != want.section {
+			t.Fatalf("Section() = %q; want %q", got, want.section)
+		}
+	}
+
+	var got Prefab
+	if err := reader.Read(&got); err != io.EOF {
+		t.Fatalf("Read() err = %v; want %v", err, io.EOF)
+	}
+}
+
+func TestWriter_NewTable(t *testing.T) {
+	var buf strings.Builder
+	csvWriter := csv.NewWriter(&buf)
+
+	writer := csvstruct.NewWriter[Prefab](csvWriter)
+	if err := writer.Write(testPrefabs[0]); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.NewTable(); err != nil {
+		t.Fatalf("NewTable() err = %v; want %v", err, nil)
+	}
+	if err := writer.Write(testPrefabs[1]); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+
+	if err := writer.NewTable(); err != nil {
+		t.Fatalf("NewTable() err = %v; want %v", err, nil)
+	}
+
+	itemWriter := csvstruct.NewWriter[Item](csvWriter)
+	if err := itemWriter.Write(Item{&Weapon{"Sword", csvstruct.Dice{1, 8, 0}}}); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := itemWriter.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want := `Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player
+Alex,Fighter,100,10,
+Info.Name,Info.Class,Attributes.HP,Attributes.Damage,Player
+Jayden,Wizard,90,20,
+Weapon.Name,Weapon.Damage
+Sword,1d8
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatalf("Write() diff = %v", diff)
+	}
+
+	csvReader := csv.NewReader(strings.NewReader(buf.String()))
+	csvReader.FieldsPerRecord = -1
+	reader := csvstruct.NewReader[Prefab](csvReader)
+
+	for _, want := range testPrefabs[:2] {
+		var got Prefab
+		if err := reader.Read(&got); err != nil {
+			t.Fatalf("Read() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("Read() diff = %v", diff)
+		}
+
+		reader.Clear()
+	}
+}
+
+func TestWithWriteComponents(t *testing.T) {
+	var buf strings.Builder
+	writer := csvstruct.NewWriter[Prefab](csv.NewWriter(&buf), csvstruct.WithWriteComponents("Player", "Info"))
+
+	for _, prefab := range testPrefabs {
+		if err := writer.Write(prefab); err != nil {
+			t.Fatalf("Write() err = %v; want %v", err, nil)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want := `Info.Name,Info.Class,Player
+Alex,Fighter,
+Jayden,Wizard,
+Mary,Queen,
+Player,,1
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatalf("Write() diff = %v", diff)
+	}
+
+	buf.Reset()
+	writer = csvstruct.NewWriter[Prefab](csv.NewWriter(&buf), csvstruct.WithWriteComponents("Info.Name", "Attributes.HP"))
+	if err := writer.Write(testPrefabs[0]); err != nil {
+		t.Fatalf("Write() err = %v; want %v", err, nil)
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() err = %v; want %v", err, nil)
+	}
+
+	want = `Info.Name,Attributes.HP
+Alex,100
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatalf("Write() diff = %v", diff)
+	}
+}
+
+func TestWithWriteComponents_Unknown(t *testing.T) {
+	writer := csvstruct.NewWriter[Prefab](csv.NewWriter(io.Discard), csvstruct.WithWriteComponents("Inventory"))
+
+	const want = `type csvstruct_test.Prefab does not have a writable component or field "Inventory"`
+	if err := writer.Write(Prefab{}); err == nil || err.Error() != want {
+		t.Errorf("Write() err = %v; want %v", err, want)
+	}
+	// Errors are permanent.
+	if err := writer.WriteHeader(); err == nil || err.Error() != want {
+		t.Errorf("WriteHeader() err = %v; want %v", err, want)
+	}
+
+	if err := csvstruct.NewTextWriter[Prefab](io.Discard, csvstruct.WithWriteComponents("Inventory")).Write(Prefab{}); err == nil || err.Error() != want {
+		t.Errorf("TextWriter.Write() err = %v; want %v", err, want)
+	}
+	if _, err := csvstruct.InsertBatches("prefabs", []Prefab{{}}, 1, csvstruct.QuestionPlaceholders, csvstruct.WithWriteComponents("Inventory")); err == nil || err.Error() != want {
+		t.Errorf("InsertBatches() err = %v; want %v", err, want)
+	}
+}
+
+type Contact struct {
+	Name  string
+	Email string `redact:"***"`
+	Age   int    `redact:""`
+}
+
+type Customer struct {
+	Contact *Contact
+}
+
+func TestWriter_Redaction(t *testing.T) {
+	rows := []Customer{
+		{&Contact{"Alex", "alex@example.com", 30}},
+		{nil},
+	}
+
+	tests := []struct {
+		opts []csvstruct.WriterOption
+		want string
+	}{
+		{nil, "Contact.Name,Contact.Email,Contact.Age\nAlex,alex@example.com,30\n,,\n"},
+		{[]csvstruct.WriterOption{csvstruct.WithRedaction()}, "Contact.Name,Contact.Email,Contact.Age\nAlex,***,\n,,\n"},
+	}
+
+	for _, test := range tests {
+		var buf strings.Builder
+		writer := csvstruct.NewWriter[Customer](csv.NewWriter(&buf), test.opts...)
+		for _, row := range rows {
+			if err := writer.Write(row); err != nil {
+				t.Fatalf("Write() err = %v; want %v", err, nil)
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatalf("Flush() err = %v; want %v", err, nil)
+		}
+
+		if diff := cmp.Diff(test.want, buf.String()); diff != "" {
+			t.Errorf("Write() diff = %v", diff)
+		}
+	}
+}